@@ -0,0 +1,248 @@
+// Package cache, kaynaklardan gelen arama, sezon ve bölüm yanıtları için
+// disk üzerinde TTL tabanlı bir önbellek içerir. Kayıtlar kaynak + endpoint
+// anahtarıyla saklanır; bölüm listeleri için stale-while-revalidate desteklenir.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/axrona/anitr-cli/internal/utils"
+)
+
+// Önbellek süreleri
+const (
+	SearchTTL   = 1 * time.Hour    // Arama sonuçları
+	SeasonsTTL  = 12 * time.Hour   // Sezon bilgileri
+	EpisodesTTL = 30 * time.Minute // Bölüm listeleri (süresi dolunca arka planda yenilenir)
+
+	// EpisodesMaxStale, süresi dolmuş bölüm listesinin en fazla ne kadar daha
+	// sunulabileceğidir; aşılırsa liste beklenerek kaynaktan alınır
+	EpisodesMaxStale = 6 * time.Hour
+
+	// revalidateTimeout, arka plandaki yenileme isteklerinin azami süresidir
+	revalidateTimeout = 30 * time.Second
+)
+
+// Key, bir önbellek kaydını tanımlar.
+type Key struct {
+	Source   string // Kaynak kimliği ("openanime", "animecix")
+	Endpoint string // Kaynağa özgü endpoint ve parametreler ("episodes/123" gibi)
+}
+
+// entry, diskte saklanan kaydın yapısıdır.
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+var (
+	mu      sync.RWMutex
+	enabled = true
+	dir     string
+
+	inflightMu sync.Mutex
+	inflight   = make(map[Key]bool)
+)
+
+// SetEnabled, önbelleği açar ya da kapatır (--no-cache).
+func SetEnabled(v bool) {
+	mu.Lock()
+	enabled = v
+	mu.Unlock()
+}
+
+// Enabled, önbelleğin açık olup olmadığını döner.
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return enabled
+}
+
+// SetDir, önbellek dizinini değiştirir. Boş değer varsayılan dizine döner.
+func SetDir(path string) {
+	mu.Lock()
+	dir = path
+	mu.Unlock()
+}
+
+// Dir, önbellek dizinini döner.
+// Öncelik sırası: SetDir ile verilen dizin, kullanıcı önbellek dizini
+// ($XDG_CACHE_HOME, ~/.cache, %LocalAppData%), ConfigDir()/cache.
+func Dir() string {
+	mu.RLock()
+	custom := dir
+	mu.RUnlock()
+	if custom != "" {
+		return custom
+	}
+
+	if base, err := os.UserCacheDir(); err == nil && base != "" {
+		return filepath.Join(base, "anitr-cli")
+	}
+	return filepath.Join(utils.ConfigDir(), "cache")
+}
+
+// path, anahtarın diskteki dosya yolunu döner.
+func (k Key) path() string {
+	sum := sha256.Sum256([]byte(k.Endpoint))
+	return filepath.Join(Dir(), k.Source, hex.EncodeToString(sum[:])+".json")
+}
+
+// load, kaydı diskten okur ve v içine çözümler; kaydın ne zaman yazıldığını döner.
+func load(key Key, v interface{}) (time.Time, error) {
+	raw, err := os.ReadFile(key.path())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return time.Time{}, fmt.Errorf("önbellek kaydı bozuk: %w", err)
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return time.Time{}, fmt.Errorf("önbellek verisi çözümlenemedi: %w", err)
+	}
+	return e.StoredAt, nil
+}
+
+// store, v'yi diske yazar. Yazma geçici dosya + rename ile yapılır,
+// böylece yarıda kalan bir yazma mevcut kaydı bozmaz.
+func store(key Key, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("önbellek verisi kodlanamadı: %w", err)
+	}
+	raw, err := json.Marshal(entry{StoredAt: time.Now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("önbellek kaydı kodlanamadı: %w", err)
+	}
+
+	path := key.path()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("önbellek dizini oluşturulamadı: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("önbellek dosyası oluşturulamadı: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("önbellek dosyası yazılamadı: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("önbellek dosyası kapatılamadı: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// refreshKey, önbellekteki kayıtların okunmayacağını işaretleyen context anahtarıdır.
+type refreshKey struct{}
+
+// WithRefresh, ctx ile yapılan Fetch ve FetchStale çağrılarının önbellekteki kayıtları
+// okumadan veriyi beklenerek fetch ile almasını sağlar; alınan veri yine önbelleğe yazılır.
+// Arka plan yenilemesini bekleyemeyen tek seferlik denetimler (watchlist check) için kullanılır.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+// refreshing, ctx'in WithRefresh ile işaretlenip işaretlenmediğini döner.
+func refreshing(ctx context.Context) bool {
+	v, _ := ctx.Value(refreshKey{}).(bool)
+	return v
+}
+
+// Fetch, kayıt önbellekte ttl süresinden yeniyse onu döner;
+// aksi hâlde fetch ile veriyi alır, önbelleğe yazar ve döner.
+// Önbellek kapalıysa doğrudan fetch çağrılır.
+func Fetch[T any](ctx context.Context, key Key, ttl time.Duration, fetch func(context.Context) (T, error)) (T, error) {
+	if !Enabled() {
+		return fetch(ctx)
+	}
+
+	var cached T
+	if !refreshing(ctx) {
+		if storedAt, err := load(key, &cached); err == nil && time.Since(storedAt) < ttl {
+			return cached, nil
+		}
+	}
+
+	data, err := fetch(ctx)
+	if err != nil {
+		return data, err
+	}
+	_ = store(key, data)
+	return data, nil
+}
+
+// FetchStale, stale-while-revalidate davranışıyla veri döner.
+// Kayıt tazeyse doğrudan, süresi dolmuşsa yine hemen döner ve arka planda yenilenir.
+// Arka plandaki yenileme süreç kapanınca yarıda kalabileceğinden, süresi maxStale'den
+// fazla geçmiş kayıtlar ve hiç olmayan kayıtlar fetch ile beklenerek alınıp önbelleğe yazılır.
+func FetchStale[T any](ctx context.Context, key Key, ttl, maxStale time.Duration, fetch func(context.Context) (T, error)) (T, error) {
+	if !Enabled() {
+		return fetch(ctx)
+	}
+
+	var cached T
+	storedAt, err := load(key, &cached)
+	if err != nil || refreshing(ctx) || time.Since(storedAt) >= ttl+maxStale {
+		data, err := fetch(ctx)
+		if err != nil {
+			return data, err
+		}
+		_ = store(key, data)
+		return data, nil
+	}
+
+	if time.Since(storedAt) >= ttl {
+		revalidate(key, fetch)
+	}
+	return cached, nil
+}
+
+// revalidate, kaydı arka planda yeniler. Aynı anahtar için aynı anda tek yenileme yapılır.
+func revalidate[T any](key Key, fetch func(context.Context) (T, error)) {
+	inflightMu.Lock()
+	if inflight[key] {
+		inflightMu.Unlock()
+		return
+	}
+	inflight[key] = true
+	inflightMu.Unlock()
+
+	go func() {
+		defer func() {
+			inflightMu.Lock()
+			delete(inflight, key)
+			inflightMu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), revalidateTimeout)
+		defer cancel()
+
+		if data, err := fetch(ctx); err == nil {
+			_ = store(key, data)
+		}
+	}()
+}
+
+// Clear, önbellek dizinini tamamen siler.
+func Clear() error {
+	path := Dir()
+	if err := os.RemoveAll(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("önbellek temizlenemedi: %w", err)
+	}
+	return nil
+}
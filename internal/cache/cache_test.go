@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// storeAt, kaydı verilen yaşla diske yazar
+func storeAt(t *testing.T, key Key, v string, age time.Duration) {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := json.Marshal(entry{StoredAt: time.Now().Add(-age), Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := key.path()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFetchStale(t *testing.T) {
+	const (
+		ttl      = 30 * time.Minute
+		maxStale = time.Hour
+	)
+
+	tests := []struct {
+		name       string
+		age        time.Duration // 0: kayıt yok
+		refresh    bool          // WithRefresh ile çağrılır
+		want       string
+		wantSync   bool // fetch dönmeden önce çağrılmalı
+		wantReval  bool // fetch arka planda çağrılmalı
+		wantStored string
+	}{
+		{name: "kayıt yok", want: "taze", wantSync: true, wantStored: "taze"},
+		{name: "taze kayıt", age: time.Minute, want: "eski", wantStored: "eski"},
+		{name: "süresi dolmuş", age: ttl + time.Minute, want: "eski", wantReval: true, wantStored: "taze"},
+		{name: "maxStale aşılmış", age: ttl + maxStale + time.Minute, want: "taze", wantSync: true, wantStored: "taze"},
+		{name: "WithRefresh", age: time.Minute, refresh: true, want: "taze", wantSync: true, wantStored: "taze"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDir(t.TempDir())
+			SetEnabled(true)
+			t.Cleanup(func() { SetDir("") })
+
+			key := Key{Source: "test", Endpoint: "episodes/1"}
+			if tt.age > 0 {
+				storeAt(t, key, "eski", tt.age)
+			}
+
+			done := make(chan struct{}, 1)
+			fetch := func(context.Context) (string, error) {
+				done <- struct{}{}
+				return "taze", nil
+			}
+
+			ctx := context.Background()
+			if tt.refresh {
+				ctx = WithRefresh(ctx)
+			}
+			got, err := FetchStale(ctx, key, ttl, maxStale, fetch)
+			if err != nil {
+				t.Fatalf("FetchStale: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FetchStale = %q, want %q", got, tt.want)
+			}
+
+			switch {
+			case tt.wantSync:
+				select {
+				case <-done:
+				default:
+					t.Fatal("fetch beklenerek çağrılmadı")
+				}
+			case tt.wantReval:
+				select {
+				case <-done:
+				case <-time.After(5 * time.Second):
+					t.Fatal("kayıt arka planda yenilenmedi")
+				}
+				// Yenileme, fetch döndükten sonra yazılır
+				deadline := time.Now().Add(5 * time.Second)
+				for {
+					var stored string
+					if _, err := load(key, &stored); err == nil && stored == tt.wantStored {
+						break
+					}
+					if time.Now().After(deadline) {
+						t.Fatal("yenilenen kayıt önbelleğe yazılmadı")
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				return
+			default:
+				select {
+				case <-done:
+					t.Fatal("taze kayıt için fetch çağrıldı")
+				default:
+				}
+			}
+
+			var stored string
+			if _, err := load(key, &stored); err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if stored != tt.wantStored {
+				t.Errorf("önbellekteki kayıt = %q, want %q", stored, tt.wantStored)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package internal
+
+import "fmt"
+
+// FieldError, bir API yanıtında beklenen alanın eksik ya da hatalı olduğunu belirtir.
+// Kaynak sitelerin şeması değiştiğinde panic yerine bu hata döner.
+type FieldError struct {
+	Endpoint string // Yanıtın geldiği endpoint ("search", "related-videos" gibi)
+	Field    string // Eksik alanın yolu ("results[3].id" gibi)
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s yanıtında '%s' alanı eksik veya beklenen formatta değil", e.Endpoint, e.Field)
+}
+
+// MissingField, verilen endpoint ve alan yolu için *FieldError oluşturur.
+func MissingField(endpoint, field string, args ...interface{}) error {
+	if len(args) > 0 {
+		field = fmt.Sprintf(field, args...)
+	}
+	return &FieldError{Endpoint: endpoint, Field: field}
+}
@@ -0,0 +1,280 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/axrona/anitr-cli/internal/models"
+	"github.com/axrona/anitr-cli/internal/sources"
+	"github.com/spf13/cobra"
+)
+
+// episodeJSON, bölüm listesinin --json çıktısındaki biçimidir
+type episodeJSON struct {
+	Index  int    `json:"index"` // streams --episode için kullanılan 1'den başlayan sıra
+	Title  string `json:"title"`
+	Number int    `json:"number"`
+	Season int    `json:"season"`
+	ID     string `json:"id,omitempty"`
+}
+
+// fansubJSON, seçilen fansub'ın --json çıktısındaki biçimidir
+type fansubJSON struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// streamJSON, tek bir çözünürlüğün --json çıktısındaki biçimidir
+type streamJSON struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// streamsJSON, streams komutunun --json çıktısıdır
+type streamsJSON struct {
+	Anime      string       `json:"anime"`
+	Episode    episodeJSON  `json:"episode"`
+	Fansub     *fansubJSON  `json:"fansub,omitempty"`
+	Streams    []streamJSON `json:"streams"`
+	CaptionURL string       `json:"caption_url,omitempty"`
+}
+
+// animeTarget, etkileşimsiz komutlarda çözümlenen anime ve bölüm listesidir
+type animeTarget struct {
+	Source   sources.Info
+	Anime    *models.Anime
+	ID       int
+	Slug     *string
+	Episodes []models.Episode
+	IsMovie  bool
+}
+
+// loadAnimeTarget, ID ya da slug ile animeyi bulur ve bölüm listesini getirir
+func loadAnimeTarget(ctx context.Context, sourceName, key string) (*animeTarget, error) {
+	info, err := resolveSource(sourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.Capabilities.Slug {
+		if _, err := strconv.Atoi(key); err != nil {
+			return nil, fmt.Errorf("%s sayısal anime ID'si bekliyor: %s", info.Name, key)
+		}
+	}
+
+	anime, err := info.Source.GetAnimeByID(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("anime bulunamadı: %w", err)
+	}
+
+	target := &animeTarget{Source: info, Anime: anime}
+	if info.Capabilities.Slug {
+		target.Slug = &key
+	} else {
+		target.ID, _ = strconv.Atoi(key)
+	}
+
+	slug := ""
+	if target.Slug != nil {
+		slug = *target.Slug
+	}
+	target.Episodes, target.IsMovie, err = sources.LoadEpisodes(ctx, info.Source, target.ID, slug, anime.Title, false)
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// toEpisodeJSON, listedeki i. bölümü çıktı biçimine çevirir
+func toEpisodeJSON(episodes []models.Episode, i int) episodeJSON {
+	ep := episodes[i]
+	return episodeJSON{
+		Index:  i + 1,
+		Title:  ep.Title,
+		Number: ep.Number,
+		Season: sources.SeasonIndexOf(ep) + 1,
+		ID:     ep.ID,
+	}
+}
+
+// newEpisodesCmd, bölüm listesini yazdıran "episodes" alt komutunu oluşturur
+func newEpisodesCmd() *cobra.Command {
+	var (
+		sourceName string
+		asJSON     bool
+	)
+
+	episodesCmd := &cobra.Command{
+		Use:   "episodes <id|slug>",
+		Short: "🔹 Animenin bölüm listesini yazdırır",
+		Long: `Verilen ID (AnimeciX) ya da slug (OpenAnime) için bölüm listesini yazdırıp çıkar.
+
+İlk sütundaki sıra numarası streams komutunun --episode bayrağında kullanılır.`,
+		Example: `  anitr-cli episodes sousou-no-frieren
+  anitr-cli episodes 42 --source animecix --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := loadAnimeTarget(cmd.Context(), sourceName, args[0])
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				out := make([]episodeJSON, 0, len(target.Episodes))
+				for i := range target.Episodes {
+					out = append(out, toEpisodeJSON(target.Episodes, i))
+				}
+				return printJSON(out)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "#\tSEZON\tBÖLÜM\tBAŞLIK")
+			for i := range target.Episodes {
+				ep := toEpisodeJSON(target.Episodes, i)
+				fmt.Fprintf(w, "%d\t%d\t%d\t%s\n", ep.Index, ep.Season, ep.Number, ep.Title)
+			}
+			return w.Flush()
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	episodesCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Kullanılacak kaynak ("+strings.Join(sourceIDs(), "|")+")")
+	episodesCmd.Flags().BoolVar(&asJSON, "json", false,
+		"Bölümleri JSON olarak yazdırır")
+
+	return episodesCmd
+}
+
+// fansubNames, fansub adlarını hata mesajları için birleştirir
+func fansubNames(fansubs []models.Fansub) string {
+	names := make([]string, 0, len(fansubs))
+	for _, f := range fansubs {
+		if f.Name != nil {
+			names = append(names, *f.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// newStreamsCmd, bir bölümün video bağlantılarını yazdıran "streams" alt komutunu oluşturur
+func newStreamsCmd() *cobra.Command {
+	var (
+		sourceName string
+		episode    int
+		fansub     string
+		asJSON     bool
+	)
+
+	streamsCmd := &cobra.Command{
+		Use:   "streams <id|slug>",
+		Short: "🔹 Bölümün video bağlantılarını ve altyazısını yazdırır",
+		Long: `Seçilen bölümün çözünürlük etiketlerini, video URL'lerini ve varsa Türkçe altyazı
+URL'sini yazdırıp çıkar. --episode, episodes çıktısındaki sıra numarasıdır; filmlerde 1'dir.
+
+Fansub destekleyen kaynaklarda --fansub ile sıra numarası, ID ya da ad verilebilir;
+belirtilmezse ilk fansub kullanılır.`,
+		Example: `  anitr-cli streams sousou-no-frieren --episode 3 --fansub 2
+  anitr-cli streams 42 --source animecix --episode 1 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			target, err := loadAnimeTarget(ctx, sourceName, args[0])
+			if err != nil {
+				return err
+			}
+
+			if episode < 1 || episode > len(target.Episodes) {
+				return fmt.Errorf("geçersiz bölüm: %d (1-%d arası olmalı)", episode, len(target.Episodes))
+			}
+			index := episode - 1
+
+			req := sources.WatchRequest{
+				Episodes:    target.Episodes,
+				Index:       index,
+				ID:          target.ID,
+				Slug:        target.Slug,
+				SeasonIndex: sources.SeasonIndexOf(target.Episodes[index]),
+				IsMovie:     target.IsMovie,
+			}
+			// Fansub belirtildiyse akışlar o fansub için çözülür
+			if fansub != "" {
+				fansubs, err := sources.Fansubs(ctx, target.Source.Source, req)
+				if err != nil {
+					return err
+				}
+				if len(fansubs) == 0 {
+					return fmt.Errorf("%s fansub seçimini desteklemiyor", target.Source.Name)
+				}
+				i, ok := sources.FindFansub(fansubs, fansub)
+				if !ok {
+					return fmt.Errorf("fansub bulunamadı: %s (mevcut: %s)", fansub, fansubNames(fansubs))
+				}
+				req.FansubIndex = i
+			}
+
+			streams, fansubs, err := sources.ResolveStreams(ctx, target.Source.Source, req)
+			if err != nil {
+				return err
+			}
+
+			out := streamsJSON{
+				Anime:      target.Anime.Title,
+				Episode:    toEpisodeJSON(target.Episodes, index),
+				Streams:    make([]streamJSON, 0, len(streams.Labels)),
+				CaptionURL: streams.CaptionURL,
+			}
+			if len(fansubs) > 0 {
+				f := fansubs[req.FansubIndex]
+				out.Fansub = &fansubJSON{}
+				if f.ID != nil {
+					out.Fansub.ID = *f.ID
+				}
+				if f.Name != nil {
+					out.Fansub.Name = *f.Name
+				}
+			}
+			for i, label := range streams.Labels {
+				out.Streams = append(out.Streams, streamJSON{Label: label, URL: streams.URLs[i]})
+			}
+
+			if asJSON {
+				return printJSON(out)
+			}
+
+			fmt.Printf("%s - %s\n", out.Anime, out.Episode.Title)
+			if out.Fansub != nil {
+				fmt.Printf("Fansub: %s\n", out.Fansub.Name)
+			}
+			if out.CaptionURL != "" {
+				fmt.Printf("Altyazı: %s\n", out.CaptionURL)
+			}
+			fmt.Println()
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KALİTE\tURL")
+			for _, s := range out.Streams {
+				fmt.Fprintf(w, "%s\t%s\n", s.Label, s.URL)
+			}
+			return w.Flush()
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	streamsCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Kullanılacak kaynak ("+strings.Join(sourceIDs(), "|")+")")
+	streamsCmd.Flags().IntVarP(&episode, "episode", "e", 1,
+		"Bölümün episodes çıktısındaki sıra numarası")
+	streamsCmd.Flags().StringVar(&fansub, "fansub", "",
+		"Fansub sıra numarası, ID'si ya da adı (fansub destekleyen kaynaklar için)")
+	streamsCmd.Flags().BoolVar(&asJSON, "json", false,
+		"Bağlantıları JSON olarak yazdırır")
+
+	return streamsCmd
+}
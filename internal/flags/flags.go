@@ -1,18 +1,25 @@
 package flags
 
 import (
+	"fmt"
 	"runtime"
 
+	"github.com/axrona/anitr-cli/internal/cache"
 	"github.com/axrona/anitr-cli/internal/update"
 	"github.com/spf13/cobra"
 )
 
 type Flags struct {
-	DisableRPC   bool
-	PrintVersion bool
-	RofiMode     bool
-	RofiFlags    string
-	QuickResume  bool
+	DisableRPC    bool
+	PrintVersion  bool
+	RofiMode      bool
+	RofiFlags     string
+	QuickResume   bool
+	NoCache       bool
+	Player        string
+	Autoplay      bool
+	AutoplayLimit int
+	Play          PlayOptions
 }
 
 func NewFlagsCmd() (*cobra.Command, *Flags) {
@@ -35,6 +42,31 @@ func NewFlagsCmd() (*cobra.Command, *Flags) {
 	cmd.PersistentFlags().BoolVar(&f.QuickResume, "go", false,
 		"Son izlenen anime bölümünü açar.")
 
+	cmd.PersistentFlags().BoolVar(&f.NoCache, "no-cache", false,
+		"Arama, sezon ve bölüm listeleri için disk önbelleğini kullanmaz.")
+
+	cmd.PersistentFlags().StringVar(&f.Player, "player", "",
+		"Kullanılacak oynatıcı (mpv|iina|vlc|command); config'teki player ayarını geçersiz kılar.")
+
+	cmd.PersistentFlags().BoolVar(&f.Autoplay, "autoplay", false,
+		"Bölüm bitince sonraki bölümü geri sayımla otomatik oynatır; config'teki autoplay ayarını geçersiz kılar.")
+
+	cmd.PersistentFlags().IntVar(&f.AutoplayLimit, "autoplay-limit", 0,
+		"Art arda otomatik oynatılacak en fazla bölüm sayısı (0: son bölüme kadar).")
+
+	// Önbellek tercihi tüm alt komutlardan önce uygulanır
+	cmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		cache.SetEnabled(!f.NoCache)
+	}
+
+	cmd.AddCommand(newCacheCmd())
+	cmd.AddCommand(newSearchCmd())
+	cmd.AddCommand(newEpisodesCmd())
+	cmd.AddCommand(newStreamsCmd())
+	cmd.AddCommand(newPlayCmd(&f.Play))
+	cmd.AddCommand(newHistoryCmd())
+	cmd.AddCommand(newWatchlistCmd())
+
 	cmd.SetVersionTemplate(update.Version())
 	cmd.Version = update.Version()
 
@@ -83,3 +115,31 @@ func NewFlagsCmd() (*cobra.Command, *Flags) {
 
 	return cmd, f
 }
+
+// newCacheCmd, önbellek yönetimi için "cache" alt komutunu oluşturur
+func newCacheCmd() *cobra.Command {
+	cacheCmd := &cobra.Command{
+		Use:           "cache",
+		Short:         "🔹 Disk önbelleğini yönetir",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Önbelleğe alınmış arama, sezon ve bölüm verilerini siler",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cache.Clear(); err != nil {
+				return err
+			}
+			fmt.Printf("Önbellek temizlendi: %s\n", cache.Dir())
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cacheCmd.AddCommand(clearCmd)
+	return cacheCmd
+}
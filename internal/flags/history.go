@@ -0,0 +1,611 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/axrona/anitr-cli/internal/models"
+	"github.com/axrona/anitr-cli/internal/sources"
+	"github.com/axrona/anitr-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// newHistoryCmd, izleme geçmişini yönetmek için "history" alt komutunu oluşturur
+func newHistoryCmd() *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:           "history",
+		Short:         "🔹 İzleme geçmişini yönetir",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	historyCmd.AddCommand(newHistoryListCmd())
+	historyCmd.AddCommand(newHistoryRmCmd())
+	historyCmd.AddCommand(newHistorySetCmd())
+	historyCmd.AddCommand(newHistoryClearCmd())
+	historyCmd.AddCommand(newHistoryExportCmd())
+	historyCmd.AddCommand(newHistoryImportCmd())
+	return historyCmd
+}
+
+// historyEntryJSON, "history list" komutunun --json çıktısındaki biçimdir
+type historyEntryJSON struct {
+	Source          string     `json:"source"`
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	LastEpisode     *int       `json:"last_episode,omitempty"` // 1'den başlar
+	LastEpisodeName string     `json:"last_episode_name,omitempty"`
+	LastWatched     *time.Time `json:"last_watched,omitempty"`
+	Watched         int        `json:"watched_episodes"`
+}
+
+// historyMatch, geçmişte bulunan bir anime kaydıdır
+type historyMatch struct {
+	Source  string
+	AnimeID string
+	Entry   utils.AnimeHistoryEntry
+}
+
+// sortedHistory, geçmişteki kayıtları son izlenme zamanına göre (yeniden eskiye) döner.
+// sourceID boş değilse yalnızca o kaynağın kayıtları döner.
+func sortedHistory(history utils.AnimeHistory, sourceID string) []historyMatch {
+	var matches []historyMatch
+	for source, animes := range history {
+		if sourceID != "" && source != sourceID {
+			continue
+		}
+		for animeId, entry := range animes {
+			matches = append(matches, historyMatch{Source: source, AnimeID: animeId, Entry: entry})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		ti, tj := utils.WatchedAt(matches[i].Entry), utils.WatchedAt(matches[j].Entry)
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return matches[i].Entry.Title < matches[j].Entry.Title
+	})
+	return matches
+}
+
+// historySourceID, --source bayrağını kaynak kimliğine çevirir; boşsa boş döner
+func historySourceID(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	info, ok := sources.Get(name)
+	if !ok {
+		return "", fmt.Errorf("bilinmeyen kaynak: %s (kullanılabilir: %s)", name, strings.Join(sourceIDs(), ", "))
+	}
+	return info.ID, nil
+}
+
+// findHistoryEntry, geçmişte ID'si, slug'ı ya da adı (büyük/küçük harf farkı gözetmeden)
+// verilen sorguyla eşleşen tek animeyi bulur. Birden fazla eşleşme varsa hata döner.
+func findHistoryEntry(history utils.AnimeHistory, sourceID, query string) (historyMatch, error) {
+	return findEntry(sortedHistory(history, ""), func(m historyMatch) entryRef {
+		return entryRef{Source: m.Source, AnimeID: m.AnimeID, Title: m.Entry.Title}
+	}, sourceID, query, "geçmişte")
+}
+
+// entryRef, geçmiş ve izleme listesi kayıtlarını aramak için kaynak, ID ve ad bilgisidir
+type entryRef struct {
+	Source  string
+	AnimeID string
+	Title   string
+}
+
+// findEntry, items içinde ID'si, slug'ı ya da adı (büyük/küçük harf farkı gözetmeden)
+// sorguyla eşleşen tek kaydı bulur; ID/slug eşleşmeleri ad eşleşmelerinden önceliklidir.
+// sourceID boş değilse yalnızca o kaynağın kayıtlarına bakılır. where, hata mesajlarında
+// kaydın arandığı yeri belirtir ("geçmişte" gibi).
+func findEntry[T any](items []T, ref func(T) entryRef, sourceID, query, where string) (T, error) {
+	query = strings.TrimSpace(query)
+
+	var byID, byTitle []T
+	for _, item := range items {
+		r := ref(item)
+		if sourceID != "" && r.Source != sourceID {
+			continue
+		}
+		switch {
+		case r.AnimeID == query:
+			byID = append(byID, item)
+		case strings.EqualFold(strings.TrimSpace(r.Title), query):
+			byTitle = append(byTitle, item)
+		}
+	}
+	matches := byID
+	if len(matches) == 0 {
+		matches = byTitle
+	}
+
+	var zero T
+	switch len(matches) {
+	case 0:
+		return zero, fmt.Errorf("%s bulunamadı: %s", where, query)
+	case 1:
+		return matches[0], nil
+	}
+
+	candidates := make([]string, 0, len(matches))
+	for _, m := range matches {
+		r := ref(m)
+		candidates = append(candidates, fmt.Sprintf("%s/%s (%s)", r.Source, r.AnimeID, r.Title))
+	}
+	return zero, fmt.Errorf("birden fazla anime eşleşti, --source ya da ID ile belirtin: %s", strings.Join(candidates, ", "))
+}
+
+func newHistoryListCmd() *cobra.Command {
+	var (
+		sourceName string
+		asJSON     bool
+	)
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Geçmişteki animeleri son izlenme zamanına göre listeler",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceID, err := historySourceID(sourceName)
+			if err != nil {
+				return err
+			}
+			history, err := utils.ReadAnimeHistory()
+			if err != nil {
+				return err
+			}
+			matches := sortedHistory(history, sourceID)
+
+			if asJSON {
+				out := make([]historyEntryJSON, 0, len(matches))
+				for _, m := range matches {
+					item := historyEntryJSON{
+						Source:          m.Source,
+						ID:              m.AnimeID,
+						Title:           m.Entry.Title,
+						LastEpisodeName: m.Entry.LastEpisodeName,
+						LastWatched:     m.Entry.LastWatched,
+						Watched:         utils.WatchedEpisodeCount(m.Entry),
+					}
+					if m.Entry.LastEpisodeIdx != nil {
+						item.LastEpisode = utils.Ptr(*m.Entry.LastEpisodeIdx + 1)
+					}
+					out = append(out, item)
+				}
+				return printJSON(out)
+			}
+
+			if len(matches) == 0 {
+				fmt.Fprintln(os.Stderr, "Geçmiş boş.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KAYNAK\tID/SLUG\tBAŞLIK\tSON BÖLÜM\tSON İZLENME")
+			for _, m := range matches {
+				episode, watchedAt := "-", "-"
+				if m.Entry.LastEpisodeIdx != nil {
+					episode = fmt.Sprintf("%d (%s)", *m.Entry.LastEpisodeIdx+1, m.Entry.LastEpisodeName)
+				}
+				if t := utils.WatchedAt(m.Entry); !t.IsZero() {
+					watchedAt = t.Local().Format("2006-01-02 15:04")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", m.Source, m.AnimeID, m.Entry.Title, episode, watchedAt)
+			}
+			return w.Flush()
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	listCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Yalnızca verilen kaynağın geçmişini listeler ("+strings.Join(sourceIDs(), "|")+")")
+	listCmd.Flags().BoolVar(&asJSON, "json", false,
+		"Geçmişi JSON olarak yazdırır")
+
+	return listCmd
+}
+
+// newHistoryRmCmd, bir animeyi geçmişten silen komutu oluşturur
+func newHistoryRmCmd() *cobra.Command {
+	var sourceName string
+
+	rmCmd := &cobra.Command{
+		Use:   "rm <anime>",
+		Short: "Animeyi geçmişten siler",
+		Long: `Animeyi ve bölüm kayıtlarını geçmişten siler.
+
+Anime, "history list" çıktısındaki ID/slug'ı ya da adıyla belirtilir.`,
+		Example: `  anitr-cli history rm "Sousou no Frieren"
+  anitr-cli history rm 42 --source animecix`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			match, err := lookupHistoryEntry(sourceName, strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			if err := utils.RemoveAnimeHistory(match.Source, match.AnimeID); err != nil {
+				return err
+			}
+			fmt.Printf("%s geçmişten silindi.\n", match.Entry.Title)
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	rmCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Animenin kaynağı ("+strings.Join(sourceIDs(), "|")+")")
+
+	return rmCmd
+}
+
+// newHistorySetCmd, animenin son izlenen bölümünü ayarlayan komutu oluşturur
+func newHistorySetCmd() *cobra.Command {
+	var (
+		sourceName string
+		episode    int
+	)
+
+	setCmd := &cobra.Command{
+		Use:   "set <anime> --episode N",
+		Short: "Animenin son izlenen bölümünü ayarlar",
+		Long: `Animenin son izlenen bölümünü N. bölüm olarak ayarlar; "Geçmiş" ve --go
+bir sonraki bölümden devam eder. Bu bölümden sonraki bölümlerin kayıtları silinir.
+
+--episode 0 izlenme bilgisini sıfırlar ve anime ilk bölümden başlar.`,
+		Example: `  anitr-cli history set "Sousou no Frieren" --episode 12
+  anitr-cli history set frieren --source openanime --episode 0`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("episode") {
+				return fmt.Errorf("--episode belirtilmeli")
+			}
+			match, err := lookupHistoryEntry(sourceName, strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			if err := utils.SetLastEpisode(match.Source, match.AnimeID, episode, ""); err != nil {
+				return err
+			}
+			if episode == 0 {
+				fmt.Printf("%s sıfırlandı, ilk bölümden başlayacak.\n", match.Entry.Title)
+			} else {
+				fmt.Printf("%s için son izlenen bölüm %d olarak ayarlandı.\n", match.Entry.Title, episode)
+			}
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	setCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Animenin kaynağı ("+strings.Join(sourceIDs(), "|")+")")
+	setCmd.Flags().IntVarP(&episode, "episode", "e", 0,
+		"Son izlenen bölüm numarası (1'den başlar, 0: sıfırla)")
+
+	return setCmd
+}
+
+// newHistoryClearCmd, bir kaynağın ya da tüm geçmişi silen komutu oluşturur
+func newHistoryClearCmd() *cobra.Command {
+	var (
+		sourceName string
+		all        bool
+	)
+
+	clearCmd := &cobra.Command{
+		Use:   "clear --source <kaynak> | --all",
+		Short: "Kaynağın ya da tüm kaynakların geçmişini siler",
+		Example: `  anitr-cli history clear --source animecix
+  anitr-cli history clear --all`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if sourceName == "" && !all {
+				return fmt.Errorf("--source ya da --all belirtilmeli")
+			}
+			sourceID, err := historySourceID(sourceName)
+			if err != nil {
+				return err
+			}
+
+			removed, err := utils.ClearAnimeHistory(sourceID)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d anime geçmişten silindi.\n", removed)
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	clearCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Geçmişi silinecek kaynak ("+strings.Join(sourceIDs(), "|")+")")
+	clearCmd.Flags().BoolVar(&all, "all", false,
+		"Tüm kaynakların geçmişini siler")
+	clearCmd.MarkFlagsMutuallyExclusive("source", "all")
+
+	return clearCmd
+}
+
+// lookupHistoryEntry, geçmişi okuyup sorguyla eşleşen animeyi bulur
+func lookupHistoryEntry(sourceName, query string) (historyMatch, error) {
+	sourceID, err := historySourceID(sourceName)
+	if err != nil {
+		return historyMatch{}, err
+	}
+	history, err := utils.ReadAnimeHistory()
+	if err != nil {
+		return historyMatch{}, err
+	}
+	return findHistoryEntry(history, sourceID, query)
+}
+
+// newHistoryExportCmd, geçmişi MAL XML, AniList JSON ya da CSV olarak dışa aktaran komutu oluşturur
+func newHistoryExportCmd() *cobra.Command {
+	var (
+		format     string
+		sourceName string
+		output     string
+	)
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Geçmişi MAL XML, AniList JSON ya da CSV olarak dışa aktarır",
+		Long: `İzleme geçmişini MyAnimeList ya da AniList'e aktarılabilecek biçimde yazdırır.
+
+Her anime için izlenen bölüm sayısı ve son izlenme tarihi yazılır. Kaynaklar
+MAL/AniList ID'si vermediğinden mal-xml ve anilist-json biçimlerinde her anime
+AniList'te adıyla aranır ve bulunan ID'ler yazılır; eşleşmeyen animeler dosyaya
+eklenmez ve standart hataya listelenir. CSV çıktısı ağ erişimi gerektirmez.
+
+Kayıtlara anitr-cli kaynağı ve ID'si de eklenir; böylece dosya "history import"
+ile arama yapılmadan geri yüklenebilir.`,
+		Example: `  anitr-cli history export --format mal-xml > animelist.xml
+  anitr-cli history export --format csv --source animecix -o gecmis.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			history, err := utils.ReadAnimeHistory()
+			if err != nil {
+				return err
+			}
+
+			if sourceName != "" {
+				sourceID, err := historySourceID(sourceName)
+				if err != nil {
+					return err
+				}
+				history = utils.AnimeHistory{sourceID: history[sourceID]}
+			}
+
+			records := utils.HistoryRecords(history)
+			if format == utils.FormatMALXML || format == utils.FormatAniListJSON {
+				site := "MyAnimeList"
+				if format == utils.FormatAniListJSON {
+					site = "AniList"
+				}
+				fmt.Fprintln(os.Stderr, "Animeler AniList'te aranıyor...")
+				if err := utils.ResolveListIDs(cmd.Context(), records); err != nil {
+					return err
+				}
+				for _, rec := range records {
+					if rec.ListID(format) == 0 {
+						fmt.Fprintf(os.Stderr, "\033[31m[!] %s için %s karşılığı bulunamadı, aktarılmadı.\033[0m\n", rec.Title, site)
+					}
+				}
+			}
+
+			if output == "" || output == "-" {
+				return utils.ExportHistory(os.Stdout, records, format)
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("çıktı dosyası oluşturulamadı: %w", err)
+			}
+			if err := utils.ExportHistory(f, records, format); err != nil {
+				f.Close()
+				return err
+			}
+			return f.Close()
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	exportCmd.Flags().StringVarP(&format, "format", "f", utils.FormatMALXML,
+		"Çıktı biçimi ("+strings.Join(utils.HistoryFormats, "|")+")")
+	exportCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Yalnızca verilen kaynağın geçmişini aktarır ("+strings.Join(sourceIDs(), "|")+")")
+	exportCmd.Flags().StringVarP(&output, "output", "o", "",
+		"Çıktının yazılacağı dosya (varsayılan: standart çıktı)")
+
+	return exportCmd
+}
+
+// newHistoryImportCmd, dışa aktarılmış bir listeyi geçmişe aktaran komutu oluşturur
+func newHistoryImportCmd() *cobra.Command {
+	var (
+		format     string
+		sourceName string
+	)
+
+	importCmd := &cobra.Command{
+		Use:   "import <dosya>",
+		Short: "MAL XML, AniList JSON ya da CSV listesini geçmişe aktarır",
+		Long: `Dışa aktarılmış bir anime listesini geçmişe ekler.
+
+anitr-cli'nin dışa aktardığı kayıtlar kaynak ve ID'leriyle doğrudan eşleştirilir.
+Diğer kayıtlar (ör. MAL ya da AniList'ten alınan listeler) --source ile verilen
+kaynakta adıyla aranır; adı birebir eşleşmeyenler atlanır.
+
+İçe aktarılan animelerde son izlenen bölüm ayarlanır; "Geçmiş" menüsü ve --go
+bir sonraki bölümden devam eder. Geçmişte daha yakın zamanda izlenmiş animeler
+değiştirilmez.
+
+Biçim belirtilmezse dosya uzantısından (.xml, .json, .csv) anlaşılır.`,
+		Example: `  anitr-cli history import animelist.xml --source animecix
+  anitr-cli history import gecmis.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format == "" {
+				var err error
+				if format, err = formatFromExt(args[0]); err != nil {
+					return err
+				}
+			}
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("dosya açılamadı: %w", err)
+			}
+			records, err := utils.ParseHistoryExport(f, format)
+			f.Close()
+			if err != nil {
+				return err
+			}
+
+			records, skipped := matchHistoryRecords(cmd.Context(), records, sourceName, os.Stderr)
+
+			imported := 0
+			err = utils.ModifyAnimeHistory(func(history utils.AnimeHistory) error {
+				for _, rec := range records {
+					if utils.SeedHistory(history, rec) {
+						imported++
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%d anime geçmişe aktarıldı", imported)
+			if unchanged := len(records) - imported; unchanged > 0 {
+				fmt.Printf(", %d anime zaten güncel", unchanged)
+			}
+			if skipped > 0 {
+				fmt.Printf(", %d anime eşleştirilemedi", skipped)
+			}
+			fmt.Println(".")
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	importCmd.Flags().StringVarP(&format, "format", "f", "",
+		"Dosya biçimi ("+strings.Join(utils.HistoryFormats, "|")+")")
+	importCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Kaynağı belli olmayan kayıtların aranacağı kaynak ("+strings.Join(sourceIDs(), "|")+")")
+
+	return importCmd
+}
+
+// formatFromExt, dosya uzantısından geçmiş biçimini tahmin eder
+func formatFromExt(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return utils.FormatMALXML, nil
+	case ".json":
+		return utils.FormatAniListJSON, nil
+	case ".csv":
+		return utils.FormatCSV, nil
+	}
+	return "", fmt.Errorf("dosya biçimi anlaşılamadı, --format ile belirtin (%s)", strings.Join(utils.HistoryFormats, "|"))
+}
+
+// matchHistoryRecords, kaynağı ve ID'si belli olmayan kayıtları verilen kaynakta adıyla arar.
+// Eşleşen kayıtlar ile eşleştirilemeyen kayıt sayısını döner; atlananlar w'ye yazılır.
+func matchHistoryRecords(ctx context.Context, records []utils.HistoryRecord, sourceName string, w io.Writer) ([]utils.HistoryRecord, int) {
+	var (
+		matched   []utils.HistoryRecord
+		info      sources.Info
+		sourceErr error
+		loaded    bool
+		skipped   int
+	)
+
+	for _, rec := range records {
+		if rec.WatchedEpisodes <= 0 {
+			continue
+		}
+
+		if rec.Source != "" && rec.AnimeID != "" {
+			known, ok := sources.Get(rec.Source)
+			if !ok {
+				fmt.Fprintf(w, "\033[31m[!] %s atlandı: bilinmeyen kaynak %s\033[0m\n", rec.Title, rec.Source)
+				skipped++
+				continue
+			}
+			rec.Source = known.ID
+			matched = append(matched, rec)
+			continue
+		}
+
+		if !loaded {
+			info, sourceErr = resolveSource(sourceName)
+			if sourceErr != nil {
+				fmt.Fprintf(w, "\033[31m[!] Kaynak seçilemedi: %v\033[0m\n", sourceErr)
+			}
+			loaded = true
+		}
+		if sourceErr != nil {
+			skipped++
+			continue
+		}
+
+		anime, err := findAnimeByTitle(ctx, info, rec.Title)
+		if err != nil {
+			fmt.Fprintf(w, "\033[31m[!] %s atlandı: %v\033[0m\n", rec.Title, err)
+			skipped++
+			continue
+		}
+
+		rec.Source = info.ID
+		rec.AnimeID = historyKey(info, anime)
+		rec.Title = anime.Title
+		matched = append(matched, rec)
+	}
+	return matched, skipped
+}
+
+// findAnimeByTitle, kaynakta adı büyük/küçük harf farkı gözetmeden birebir eşleşen animeyi arar
+func findAnimeByTitle(ctx context.Context, info sources.Info, title string) (models.Anime, error) {
+	results, err := info.Source.GetSearchData(ctx, title)
+	if err != nil {
+		return models.Anime{}, fmt.Errorf("%s araması başarısız: %w", info.Name, err)
+	}
+	for _, a := range results {
+		if strings.EqualFold(strings.TrimSpace(a.Title), strings.TrimSpace(title)) && historyKey(info, a) != "" {
+			return a, nil
+		}
+	}
+	return models.Anime{}, fmt.Errorf("%s kaynağında birebir eşleşen anime bulunamadı", info.Name)
+}
+
+// historyKey, animenin geçmişte tutulduğu anahtarı döner.
+// Slug kullanan kaynaklarda slug, diğerlerinde sayısal ID kullanılır.
+func historyKey(info sources.Info, a models.Anime) string {
+	if info.Capabilities.Slug {
+		if a.Slug != nil {
+			return *a.Slug
+		}
+		return ""
+	}
+	if a.ID != nil {
+		return strconv.Itoa(*a.ID)
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// PlayOptions, play alt komutunun bayraklarıdır
+type PlayOptions struct {
+	Source  string // Kaynak kimliği ya da adı (boşsa config/varsayılan kaynak)
+	Episode int    // 1'den başlayan bölüm sırası (0: geçmişe göre sıradaki bölüm)
+	Quality string // Çözünürlük etiketi ("1080p" gibi; boşsa en yüksek)
+	Fansub  string // Fansub sıra numarası, ID'si ya da adı
+}
+
+// newPlayCmd, menüsüz oynatma için "play" alt komutunu oluşturur.
+// Run, uygulama durumuna eriştiği için main paketinde atanır.
+func newPlayCmd(o *PlayOptions) *cobra.Command {
+	playCmd := &cobra.Command{
+		Use:   "play <sorgu|id|slug>",
+		Short: "🔹 Animeyi menüsüz olarak doğrudan oynatır",
+		Long: `Verilen sorgu, ID ya da slug için bölümü menüye girmeden oynatır.
+
+Sorgu birden fazla animeyle eşleşirse, istenen kalite ya da fansub bulunamazsa
+ilgili seçim etkileşimli olarak sorulur. Geçmiş ve Discord RPC etkileşimli
+oynatmadaki gibi güncellenir.`,
+		Example: `  anitr-cli play "sousou no frieren" --episode 5 --quality 1080p
+  anitr-cli play 42 --source animecix --episode 1
+  anitr-cli play sousou-no-frieren --fansub AnimeTR`,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	playCmd.Flags().StringVarP(&o.Source, "source", "s", "",
+		"Kullanılacak kaynak ("+strings.Join(sourceIDs(), "|")+")")
+	playCmd.Flags().IntVarP(&o.Episode, "episode", "e", 0,
+		"Oynatılacak bölümün sırası (belirtilmezse geçmişe göre sıradaki bölüm)")
+	playCmd.Flags().StringVarP(&o.Quality, "quality", "q", "",
+		"Çözünürlük (örnek: 1080p; belirtilmezse en yüksek)")
+	playCmd.Flags().StringVar(&o.Fansub, "fansub", "",
+		"Fansub sıra numarası, ID'si ya da adı (fansub destekleyen kaynaklar için)")
+
+	return playCmd
+}
@@ -0,0 +1,170 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/axrona/anitr-cli/internal/httpx"
+	"github.com/axrona/anitr-cli/internal/models"
+	"github.com/axrona/anitr-cli/internal/sources"
+	"github.com/axrona/anitr-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// animeJSON, arama sonuçlarının --json çıktısındaki biçimidir
+type animeJSON struct {
+	Title     string  `json:"title"`
+	ID        *int    `json:"id,omitempty"`
+	Slug      *string `json:"slug,omitempty"`
+	Type      *string `json:"type,omitempty"`
+	TitleType *string `json:"title_type,omitempty"`
+	Poster    string  `json:"poster"`
+	Source    string  `json:"source"`
+}
+
+// newSearchCmd, etkileşimsiz arama için "search" alt komutunu oluşturur
+func newSearchCmd() *cobra.Command {
+	var (
+		sourceName string
+		asJSON     bool
+	)
+
+	searchCmd := &cobra.Command{
+		Use:   "search <sorgu>",
+		Short: "🔹 Anime arar ve sonuçları tablo ya da JSON olarak yazdırır",
+		Long: `Seçilen kaynakta anime arar ve sonuçları yazdırıp çıkar.
+
+Kaynak belirtilmezse config'teki default_source, o da yoksa varsayılan kaynak kullanılır.`,
+		Example: `  anitr-cli search "sousou no frieren"
+  anitr-cli search frieren --source animecix --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := resolveSource(sourceName)
+			if err != nil {
+				return err
+			}
+
+			query := strings.Join(args, " ")
+			results, err := info.Source.GetSearchData(cmd.Context(), query)
+			if err != nil {
+				return fmt.Errorf("%s araması başarısız: %w", info.Name, err)
+			}
+
+			if asJSON {
+				out := make([]animeJSON, 0, len(results))
+				for _, a := range results {
+					out = append(out, animeJSON{
+						Title:     a.Title,
+						ID:        a.ID,
+						Slug:      a.Slug,
+						Type:      a.Type,
+						TitleType: a.TitleType,
+						Poster:    a.ImageURL,
+						Source:    info.ID,
+					})
+				}
+				return printJSON(out)
+			}
+
+			if len(results) == 0 {
+				fmt.Fprintln(os.Stderr, "Sonuç bulunamadı.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "BAŞLIK\tID/SLUG\tTÜR\tPOSTER")
+			for _, a := range results {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Title, animeKey(a), animeType(a), a.ImageURL)
+			}
+			return w.Flush()
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	searchCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Aramanın yapılacağı kaynak ("+strings.Join(sourceIDs(), "|")+")")
+	searchCmd.Flags().BoolVar(&asJSON, "json", false,
+		"Sonuçları JSON olarak yazdırır")
+
+	return searchCmd
+}
+
+// resolveSource, etkileşimsiz komutlar için kaynağı seçer ve config'teki HTTP ayarlarını uygular.
+// Öncelik sırası: --source bayrağı, config'teki default_source, varsayılan kaynak.
+func resolveSource(name string) (sources.Info, error) {
+	if cfg := applyConfig(); cfg != nil && name == "" {
+		name = cfg.DefaultSource
+	}
+
+	if name == "" {
+		info, ok := sources.Default()
+		if !ok {
+			return sources.Info{}, fmt.Errorf("kayıtlı anime kaynağı bulunamadı")
+		}
+		return info, nil
+	}
+
+	info, ok := sources.Get(name)
+	if !ok {
+		return sources.Info{}, fmt.Errorf("bilinmeyen kaynak: %s (kullanılabilir: %s)", name, strings.Join(sourceIDs(), ", "))
+	}
+	return info, nil
+}
+
+// applyConfig, config'teki HTTP ve paralel istek ayarlarını uygular ve config'i döner.
+// Config okunamazsa varsayılanlarla devam edilir ve nil döner.
+func applyConfig() *utils.Config {
+	cfg, err := utils.LoadConfig(filepath.Join(utils.ConfigDir(), "config.json"))
+	if err != nil {
+		return nil
+	}
+	httpx.Configure(cfg.HTTPOptions())
+	sources.SetWorkers(cfg.FetchWorkers)
+	return cfg
+}
+
+// sourceIDs, kayıtlı kaynakların kimliklerini menü sırasına göre döner
+func sourceIDs() []string {
+	list := sources.List()
+	ids := make([]string, 0, len(list))
+	for _, info := range list {
+		ids = append(ids, info.ID)
+	}
+	return ids
+}
+
+// printJSON, verilen değeri girintili JSON olarak standart çıktıya yazar
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// animeKey, animenin kaynağa göre ID'sini ya da slug'ını döner
+func animeKey(a models.Anime) string {
+	switch {
+	case a.Slug != nil:
+		return *a.Slug
+	case a.ID != nil:
+		return strconv.Itoa(*a.ID)
+	}
+	return "-"
+}
+
+// animeType, varsa başlık türünü, yoksa içerik türünü döner
+func animeType(a models.Anime) string {
+	switch {
+	case a.TitleType != nil && *a.TitleType != "":
+		return *a.TitleType
+	case a.Type != nil && *a.Type != "":
+		return *a.Type
+	}
+	return "-"
+}
@@ -0,0 +1,301 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/axrona/anitr-cli/internal/utils"
+	"github.com/axrona/anitr-cli/internal/watchlist"
+	"github.com/spf13/cobra"
+)
+
+// watchlistStatusJSON, "watchlist check" komutunun --json çıktısındaki biçimdir
+type watchlistStatusJSON struct {
+	Source      string `json:"source"`
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Episodes    int    `json:"episodes"`
+	LastWatched int    `json:"last_watched"` // 1'den başlar, 0: hiç izlenmedi
+	NewEpisodes int    `json:"new_episodes"`
+	Error       string `json:"error,omitempty"`
+}
+
+// watchlistEntryJSON, "watchlist list" komutunun --json çıktısındaki biçimdir
+type watchlistEntryJSON struct {
+	Source  string    `json:"source"`
+	ID      string    `json:"id"`
+	Title   string    `json:"title"`
+	Poster  string    `json:"poster,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// watchlistMatch, izleme listesinde bulunan bir anime kaydıdır
+type watchlistMatch struct {
+	Source  string
+	AnimeID string
+	Entry   utils.WatchlistEntry
+}
+
+// newWatchlistCmd, izleme listesini yönetmek için "watchlist" alt komutunu oluşturur
+func newWatchlistCmd() *cobra.Command {
+	watchlistCmd := &cobra.Command{
+		Use:           "watchlist",
+		Short:         "🔹 İzleme listesini yönetir ve yeni bölümleri denetler",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	watchlistCmd.AddCommand(newWatchlistCheckCmd())
+	watchlistCmd.AddCommand(newWatchlistListCmd())
+	watchlistCmd.AddCommand(newWatchlistAddCmd())
+	watchlistCmd.AddCommand(newWatchlistRmCmd())
+	return watchlistCmd
+}
+
+// newWatchlistCheckCmd, izleme listesindeki animelerin yeni bölümlerini denetleyen komutu oluşturur
+func newWatchlistCheckCmd() *cobra.Command {
+	var asJSON bool
+
+	checkCmd := &cobra.Command{
+		Use:   "check",
+		Short: "İzleme listesindeki animelerde yeni bölüm olup olmadığını denetler",
+		Long: `İzleme listesindeki her anime için bölüm listesini kaynaktan yeniden alır ve
+geçmişteki son izlenen bölümden sonra yayınlanmış bölümleri listeler.
+
+Bölüm listeleri önbellekten okunmaz, her denetimde kaynaktan alınır; alınan
+listeler önbelleğe yazılır.`,
+		Example: `  anitr-cli watchlist check
+  anitr-cli watchlist check --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applyConfig()
+
+			list, err := utils.ReadWatchlist()
+			if err != nil {
+				return err
+			}
+			history, err := utils.ReadAnimeHistory()
+			if err != nil {
+				return err
+			}
+			statuses, err := watchlist.Check(cmd.Context(), list, history)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				out := make([]watchlistStatusJSON, 0, len(statuses))
+				for _, st := range statuses {
+					item := watchlistStatusJSON{
+						Source:      st.Source,
+						ID:          st.AnimeID,
+						Title:       st.Title,
+						Episodes:    st.Episodes,
+						LastWatched: st.LastWatched,
+						NewEpisodes: st.NewEpisodes,
+					}
+					if st.Err != nil {
+						item.Error = st.Err.Error()
+					}
+					out = append(out, item)
+				}
+				return printJSON(out)
+			}
+
+			if len(statuses) == 0 {
+				fmt.Fprintln(os.Stderr, "İzleme listesi boş.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "BAŞLIK\tKAYNAK\tSON İZLENEN\tBÖLÜM\tYENİ")
+			for _, st := range statuses {
+				if st.Err != nil {
+					fmt.Fprintf(os.Stderr, "\033[31m[!] %s denetlenemedi: %v\033[0m\n", st.Title, st.Err)
+					continue
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", st.Title, st.Source, st.LastWatched, st.Episodes, st.NewEpisodes)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			if n := watchlist.NewEpisodeCount(statuses); n > 0 {
+				fmt.Printf("\n%d animede yeni bölüm var.\n", n)
+			} else {
+				fmt.Println("\nYeni bölüm yok.")
+			}
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	checkCmd.Flags().BoolVar(&asJSON, "json", false,
+		"Sonuçları JSON olarak yazdırır")
+
+	return checkCmd
+}
+
+// newWatchlistListCmd, izleme listesini yazdıran komutu oluşturur
+func newWatchlistListCmd() *cobra.Command {
+	var asJSON bool
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "İzleme listesini yazdırır",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := utils.ReadWatchlist()
+			if err != nil {
+				return err
+			}
+			matches := sortedWatchlist(list)
+
+			if asJSON {
+				out := make([]watchlistEntryJSON, 0, len(matches))
+				for _, m := range matches {
+					out = append(out, watchlistEntryJSON{
+						Source:  m.Source,
+						ID:      m.AnimeID,
+						Title:   m.Entry.Title,
+						Poster:  m.Entry.Poster,
+						AddedAt: m.Entry.AddedAt,
+					})
+				}
+				return printJSON(out)
+			}
+
+			if len(matches) == 0 {
+				fmt.Fprintln(os.Stderr, "İzleme listesi boş.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KAYNAK\tID/SLUG\tBAŞLIK\tEKLENME")
+			for _, m := range matches {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Source, m.AnimeID, m.Entry.Title, m.Entry.AddedAt.Local().Format("2006-01-02"))
+			}
+			return w.Flush()
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	listCmd.Flags().BoolVar(&asJSON, "json", false,
+		"Listeyi JSON olarak yazdırır")
+
+	return listCmd
+}
+
+// newWatchlistAddCmd, animeyi ID'si ya da slug'ıyla izleme listesine ekleyen komutu oluşturur
+func newWatchlistAddCmd() *cobra.Command {
+	var sourceName string
+
+	addCmd := &cobra.Command{
+		Use:   "add <id|slug>",
+		Short: "Animeyi izleme listesine ekler",
+		Long: `Animeyi kaynaktaki ID'si (ya da slug'ı) ile izleme listesine ekler.
+ID'ler "search" komutunun çıktısında yer alır.`,
+		Example: `  anitr-cli watchlist add 42 --source animecix
+  anitr-cli watchlist add sousou-no-frieren --source openanime`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := resolveSource(sourceName)
+			if err != nil {
+				return err
+			}
+
+			anime, err := info.Source.GetAnimeByID(cmd.Context(), args[0])
+			if err != nil || anime == nil {
+				return fmt.Errorf("%s kaynağında anime bulunamadı: %s", info.Name, args[0])
+			}
+			if info.Capabilities.Slug && anime.Slug == nil {
+				anime.Slug = &args[0]
+			}
+
+			key := historyKey(info, *anime)
+			entry := utils.WatchlistEntry{Title: anime.Title, Poster: anime.ImageURL}
+			if err := utils.AddToWatchlist(info.ID, key, entry); err != nil {
+				return err
+			}
+			fmt.Printf("%s izleme listesine eklendi.\n", anime.Title)
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	addCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Animenin kaynağı ("+strings.Join(sourceIDs(), "|")+")")
+
+	return addCmd
+}
+
+// newWatchlistRmCmd, animeyi izleme listesinden çıkaran komutu oluşturur
+func newWatchlistRmCmd() *cobra.Command {
+	var sourceName string
+
+	rmCmd := &cobra.Command{
+		Use:   "rm <anime>",
+		Short: "Animeyi izleme listesinden çıkarır",
+		Long: `Animeyi izleme listesinden çıkarır. Anime, "watchlist list" çıktısındaki
+ID/slug'ı ya da adıyla belirtilir.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceID, err := historySourceID(sourceName)
+			if err != nil {
+				return err
+			}
+			list, err := utils.ReadWatchlist()
+			if err != nil {
+				return err
+			}
+			match, err := findWatchlistEntry(list, sourceID, strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			if err := utils.RemoveFromWatchlist(match.Source, match.AnimeID); err != nil {
+				return err
+			}
+			fmt.Printf("%s izleme listesinden çıkarıldı.\n", match.Entry.Title)
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	rmCmd.Flags().StringVarP(&sourceName, "source", "s", "",
+		"Animenin kaynağı ("+strings.Join(sourceIDs(), "|")+")")
+
+	return rmCmd
+}
+
+// sortedWatchlist, izleme listesini ada göre sıralı döner
+func sortedWatchlist(list utils.Watchlist) []watchlistMatch {
+	var matches []watchlistMatch
+	for source, animes := range list {
+		for animeId, entry := range animes {
+			matches = append(matches, watchlistMatch{Source: source, AnimeID: animeId, Entry: entry})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Entry.Title != matches[j].Entry.Title {
+			return matches[i].Entry.Title < matches[j].Entry.Title
+		}
+		return matches[i].Source < matches[j].Source
+	})
+	return matches
+}
+
+// findWatchlistEntry, izleme listesinde ID'si, slug'ı ya da adı verilen sorguyla eşleşen
+// tek animeyi bulur. ID/slug eşleşmeleri ad eşleşmelerinden önceliklidir.
+func findWatchlistEntry(list utils.Watchlist, sourceID, query string) (watchlistMatch, error) {
+	return findEntry(sortedWatchlist(list), func(m watchlistMatch) entryRef {
+		return entryRef{Source: m.Source, AnimeID: m.AnimeID, Title: m.Entry.Title}
+	}, sourceID, query, "izleme listesinde")
+}
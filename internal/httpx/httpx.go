@@ -0,0 +1,277 @@
+// Package httpx, kaynakların ve güncelleme denetiminin kullandığı ortak HTTP istemcisini içerir.
+// Tüm istekler bir context.Context ile yapılır; zaman aşımı, 5xx ve ağ zaman aşımı
+// hatalarında artan beklemeli (backoff) yeniden deneme desteklenir.
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Options, HTTP istemcisinin davranışını belirler.
+type Options struct {
+	Timeout time.Duration // Tek bir denemenin azami süresi (yanıt gövdesinin okunması dahil)
+	Retries int           // 5xx ve zaman aşımı hatalarında yapılacak ek deneme sayısı
+	Backoff time.Duration // İlk yeniden denemeden önceki bekleme; her denemede iki katına çıkar
+}
+
+// DefaultOptions, yapılandırma verilmediğinde kullanılan varsayılan ayarlardır.
+var DefaultOptions = Options{
+	Timeout: 15 * time.Second,
+	Retries: 2,
+	Backoff: 500 * time.Millisecond,
+}
+
+// StatusError, sunucunun başarısız bir HTTP durum kodu döndürdüğünü belirtir.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.URL)
+}
+
+// Client, context destekli ve yeniden deneme yapan HTTP istemcisidir.
+type Client struct {
+	opts Options
+	http *http.Client
+}
+
+// New, verilen ayarlarla yeni bir istemci oluşturur.
+// Sıfır değerli ayarlar için DefaultOptions kullanılır.
+func New(opts Options) *Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultOptions.Timeout
+	}
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultOptions.Backoff
+	}
+
+	return &Client{
+		opts: opts,
+		http: &http.Client{Timeout: opts.Timeout},
+	}
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultClient = New(DefaultOptions)
+)
+
+// Default, paket genelinde kullanılan istemciyi döner.
+func Default() *Client {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultClient
+}
+
+// Configure, paket genelinde kullanılan istemciyi verilen ayarlarla yeniden oluşturur.
+func Configure(opts Options) {
+	c := New(opts)
+
+	defaultMu.Lock()
+	defaultClient = c
+	defaultMu.Unlock()
+}
+
+// Options, istemcinin kullandığı ayarları döner.
+func (c *Client) Options() Options {
+	return c.opts
+}
+
+// retryable, hatanın yeniden denemeye uygun olup olmadığını döner.
+func retryable(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// wait, bir sonraki deneme öncesinde bekler; context iptal edilirse hemen döner.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Do, verilen metot ve başlıklarla gövdesiz istek gönderir.
+// 5xx yanıtları ve zaman aşımları için yeniden dener; diğer yanıtlar olduğu gibi döner.
+// Dönen yanıtın gövdesini kapatmak çağıranın sorumluluğundadır.
+func (c *Client) Do(ctx context.Context, method, url string, headers map[string]string) (*http.Response, error) {
+	return c.do(ctx, method, url, headers, nil)
+}
+
+// do, Do'nun gövdeli isteklerle de çalışan hâlidir. Gövde her denemede baştan gönderilir.
+func (c *Client) do(ctx context.Context, method, url string, headers map[string]string, body []byte) (*http.Response, error) {
+	backoff := c.opts.Backoff
+	var lastErr error
+
+	for attempt := 0; attempt <= c.opts.Retries; attempt++ {
+		if attempt > 0 {
+			if err := wait(ctx, backoff); err != nil {
+				return nil, err
+			}
+			backoff *= 2
+		}
+
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reader)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP isteği oluşturulamadı: %w", err)
+		}
+
+		// İstek başlıklarını ayarla
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			// Kullanıcı iptali ya da üst context'in süresi dolduysa tekrar deneme
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			lastErr = err
+			if retryable(err) {
+				continue
+			}
+			return nil, fmt.Errorf("HTTP isteği başarısız: %w", err)
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			resp.Body.Close()
+			lastErr = &StatusError{URL: url, StatusCode: resp.StatusCode}
+			continue
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("HTTP isteği %d denemede başarısız: %w", c.opts.Retries+1, lastErr)
+}
+
+// Get, GET isteği gönderir.
+func (c *Client) Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	return c.Do(ctx, http.MethodGet, url, headers)
+}
+
+// Head, HEAD isteği gönderir.
+func (c *Client) Head(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	return c.Do(ctx, http.MethodHead, url, headers)
+}
+
+// GetBytes, GET isteği gönderir ve başarılı yanıtın gövdesini döner.
+// 2xx dışındaki durum kodları *StatusError olarak döner.
+func (c *Client) GetBytes(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	resp, err := c.Get(ctx, url, headers)
+	if err != nil {
+		return nil, err
+	}
+	return readBody(ctx, url, resp)
+}
+
+// PostJSON, in'i JSON olarak POST eder ve başarılı JSON yanıtını out içine çözümler.
+// 2xx dışındaki durum kodları *StatusError olarak döner.
+func (c *Client) PostJSON(ctx context.Context, url string, headers map[string]string, in, out interface{}) error {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("JSON kodlama başarısız: %w", err)
+	}
+
+	all := map[string]string{"Content-Type": "application/json", "Accept": "application/json"}
+	for k, v := range headers {
+		all[k] = v
+	}
+	resp, err := c.do(ctx, http.MethodPost, url, all, payload)
+	if err != nil {
+		return err
+	}
+	body, err := readBody(ctx, url, resp)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("JSON ayrıştırma başarısız: %w", err)
+	}
+	return nil
+}
+
+// readBody, başarılı yanıtın gövdesini okuyup kapatır.
+// 2xx dışındaki durum kodları *StatusError olarak döner.
+func readBody(ctx context.Context, url string, resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("HTTP yanıtı okunamadı: %w", err)
+	}
+	return body, nil
+}
+
+// GetJSON, GET isteği gönderir ve JSON yanıtını v içine çözümler.
+func (c *Client) GetJSON(ctx context.Context, url string, headers map[string]string, v interface{}) error {
+	body, err := c.GetBytes(ctx, url, headers)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("JSON ayrıştırma başarısız: %w", err)
+	}
+	return nil
+}
+
+// Get, varsayılan istemciyle GET isteği gönderir.
+func Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	return Default().Get(ctx, url, headers)
+}
+
+// Head, varsayılan istemciyle HEAD isteği gönderir.
+func Head(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	return Default().Head(ctx, url, headers)
+}
+
+// GetBytes, varsayılan istemciyle GET isteği gönderir ve yanıt gövdesini döner.
+func GetBytes(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	return Default().GetBytes(ctx, url, headers)
+}
+
+// GetJSON, varsayılan istemciyle GET isteği gönderir ve JSON yanıtını çözümler.
+func GetJSON(ctx context.Context, url string, headers map[string]string, v interface{}) error {
+	return Default().GetJSON(ctx, url, headers, v)
+}
+
+// PostJSON, varsayılan istemciyle JSON POST isteği gönderir ve JSON yanıtını çözümler.
+func PostJSON(ctx context.Context, url string, headers map[string]string, in, out interface{}) error {
+	return Default().PostJSON(ctx, url, headers, in, out)
+}
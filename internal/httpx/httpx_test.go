@@ -0,0 +1,106 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// statusServer, her istekte sıradaki durum kodunu dönen bir test sunucusu başlatır;
+// kodlar bitince son kod tekrarlanır
+func statusServer(t *testing.T, codes ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(calls.Add(1)) - 1
+		if i >= len(codes) {
+			i = len(codes) - 1
+		}
+		w.WriteHeader(codes[i])
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestGetBytesRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		retries   int
+		codes     []int
+		wantCalls int32
+		wantCode  int // 0: hata beklenmez
+		wrapped   bool
+	}{
+		{name: "5xx sonrası başarılı", retries: 2, codes: []int{503, 502, 200}, wantCalls: 3},
+		{name: "4xx yeniden denenmez", retries: 2, codes: []int{404}, wantCalls: 1, wantCode: 404},
+		{name: "yeniden deneme kapalı", retries: 0, codes: []int{500, 200}, wantCalls: 1, wantCode: 500, wrapped: true},
+		{name: "denemeler tükenir", retries: 2, codes: []int{500}, wantCalls: 3, wantCode: 500, wrapped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := statusServer(t, tt.codes...)
+			c := New(Options{Timeout: time.Second, Retries: tt.retries, Backoff: time.Millisecond})
+
+			body, err := c.GetBytes(context.Background(), srv.URL, nil)
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("istek sayısı = %d, want %d", got, tt.wantCalls)
+			}
+
+			if tt.wantCode == 0 {
+				if err != nil || string(body) != "ok" {
+					t.Fatalf("GetBytes = %q, %v", body, err)
+				}
+				return
+			}
+
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.wantCode {
+				t.Fatalf("hata = %v, want HTTP %d", err, tt.wantCode)
+			}
+			// 5xx denemeleri tükenince StatusError deneme sayısını belirten hatayla sarılır
+			if _, isBare := err.(*StatusError); isBare == tt.wrapped {
+				t.Errorf("hata sarılması = %v, want %v: %v", !isBare, tt.wrapped, err)
+			}
+		})
+	}
+}
+
+func TestDoCancelDuringBackoff(t *testing.T) {
+	srv, calls := statusServer(t, 500)
+	c := New(Options{Timeout: time.Second, Retries: 3, Backoff: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// İlk deneme bitip beklemeye geçildikten sonra iptal edilir
+		for calls.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Do(ctx, http.MethodGet, srv.URL, nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) || err != ctx.Err() {
+			t.Errorf("hata = %v, want ctx.Err()", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("iptal edilen istek beklemeden dönmedi")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("istek sayısı = %d, want 1", got)
+	}
+}
@@ -2,10 +2,6 @@
 package internal
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"time"
 )
 
@@ -17,6 +13,26 @@ type Config struct {
 	HttpHeaders    map[string]string // HTTP isteklerinde kullanılacak başlıklar
 }
 
+// Override, yapılandırmadaki adresleri o'daki dolu alanlarla değiştirir ve
+// önceki değerleri geri yükleyen fonksiyonu döner. Testlerde kaynakları
+// yerel bir sunucuya yönlendirmek için kullanılır.
+func (c *Config) Override(o Config) (restore func()) {
+	old := *c
+	if o.BaseUrl != "" {
+		c.BaseUrl = o.BaseUrl
+	}
+	if o.AlternativeUrl != "" {
+		c.AlternativeUrl = o.AlternativeUrl
+	}
+	if len(o.VideoPlayers) > 0 {
+		c.VideoPlayers = o.VideoPlayers
+	}
+	if o.HttpHeaders != nil {
+		c.HttpHeaders = o.HttpHeaders
+	}
+	return func() { *c = old }
+}
+
 // UiParams, UI (kullanıcı arayüzü) ile ilgili parametreleri temsil eder.
 type UiParams struct {
 	Mode               string    // Arayüz modu: "rofi" veya "tui"
@@ -25,6 +41,7 @@ type UiParams struct {
 	RofiFlags          *string   // Rofi'ye özel ek parametreler (varsa)
 	SkipSeasonSeparators bool    // Sezon ayırıcılarını atla (geçmiş menüsü için)
 	SkipAllSeparators    bool    // Tüm separator'ları atla
+	Badges               map[string]string // Öğe -> yanında gösterilecek durum (ör. izlendi işareti)
 }
 
 // RPCParams, Discord Rich Presence için gönderilecek bilgileri içerir.
@@ -56,38 +73,3 @@ func GetString(m map[string]interface{}, key string) string {
 	}
 	return ""
 }
-
-// GetJson, verilen URL'ye HTTP GET isteği gönderir, gelen JSON yanıtı çözümler.
-// Başarılı olursa çözülmüş veriyi interface{} olarak döner, aksi hâlde hata döner.
-func GetJson(url string, headers map[string]string) (interface{}, error) {
-	client := &http.Client{}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP isteği oluşturulamadı: %w", err)
-	}
-
-	// İstek başlıklarını ayarla
-	for k, m := range headers {
-		req.Header.Set(k, m)
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP isteği başarısız: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP yanıtı okunamadı: %w", err)
-	}
-
-	var result interface{}
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return nil, fmt.Errorf("JSON ayrıştırma başarısız: %w", err)
-	}
-
-	return result, nil
-}
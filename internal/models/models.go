@@ -1,22 +1,49 @@
 // models paketi, anime verilerini ve ilgili yapılarını tanımlar.
 package models
 
+import "context"
+
 // AnimeSource arayüzü, farklı anime kaynaklarından veri çekme işlevlerini tanımlar.
+// Ağ isteği yapan tüm metotlar iptal ve zaman aşımı için bir context.Context alır.
+// Kaynağa özgü özellikler aşağıdaki isteğe bağlı arayüzlerle (FansubProvider,
+// SubtitleProvider, MovieProvider, SeasonProvider) sunulur.
 type AnimeSource interface {
 	// Arama sorgusuna göre anime verilerini getirir.
-	GetSearchData(query string) ([]Anime, error)
+	GetSearchData(ctx context.Context, query string) ([]Anime, error)
 	// Id/Slug ile anime verisini getirir.
-	GetAnimeByID(id string) (*Anime, error)
-	// Sezon verilerini getirir.
-	GetSeasonsData(params SeasonParams) ([]Season, error)
+	GetAnimeByID(ctx context.Context, id string) (*Anime, error)
 	// Bölüm verilerini getirir.
-	GetEpisodesData(params EpisodeParams) ([]Episode, error)
+	GetEpisodesData(ctx context.Context, params EpisodeParams) ([]Episode, error)
 	// İzleme verilerini getirir.
-	GetWatchData(params WatchParams) ([]Watch, error)
+	GetWatchData(ctx context.Context, params WatchParams) ([]Watch, error)
 	// Kaynağın adını döner.
 	Source() string
 }
 
+// FansubProvider, bölüm başına fansub seçimi sunan kaynaklar tarafından uygulanır.
+type FansubProvider interface {
+	// Bölüm için fansub listesini getirir.
+	GetFansubsData(ctx context.Context, params FansubParams) ([]Fansub, error)
+}
+
+// SubtitleProvider, izleme verisinden ayrı olarak seçilebilir altyazılar sunan kaynaklar tarafından uygulanır.
+type SubtitleProvider interface {
+	// Bölüm ya da film için mevcut altyazıları getirir.
+	GetSubtitlesData(ctx context.Context, params SubtitleParams) ([]Subtitle, error)
+}
+
+// MovieProvider, film içeriklerini dizilerden ayırt edebilen kaynaklar tarafından uygulanır.
+type MovieProvider interface {
+	// İçeriğin film olup olmadığını döner.
+	IsMovie(ctx context.Context, params SeasonParams) (bool, error)
+}
+
+// SeasonProvider, birden fazla sezon içeren animeleri sunan kaynaklar tarafından uygulanır.
+type SeasonProvider interface {
+	// Sezon verilerini getirir.
+	GetSeasonsData(ctx context.Context, params SeasonParams) ([]Season, error)
+}
+
 // Anime yapısı, bir anime hakkında temel bilgileri içerir.
 type Anime struct {
 	Title     string                 // Anime başlığı
@@ -52,6 +79,12 @@ type Fansub struct {
 	SecureName *string // Fansub güvenli adı (nullable)
 }
 
+// Subtitle yapısı, bir bölüm için sunulan altyazı dosyasını temsil eder.
+type Subtitle struct {
+	Language string // Altyazı dili ("tr", "en" vb.)
+	Url      string // Altyazı dosyasının URL'si
+}
+
 // WatchParams yapısı, izleme işlemi için gerekli parametreleri içerir.
 type WatchParams struct {
 	Slug    *string                 // Anime URL dostu adı (nullable)
@@ -69,6 +102,15 @@ type FansubParams struct {
 	EpisodeNum *int    // Bölüm numarası (nullable)
 }
 
+// SubtitleParams yapısı, bir bölümün altyazılarını almak için gerekli parametreleri içerir.
+type SubtitleParams struct {
+	Slug         *string // Anime URL dostu adı (nullable)
+	Id           *int    // Anime ID'si (nullable)
+	SeasonIndex  *int    // Sıfırdan başlayan sezon indeksi (nullable)
+	EpisodeIndex *int    // Sezon içindeki sıfırdan başlayan bölüm indeksi (nullable)
+	IsMovie      *bool   // Anime'nin film olup olmadığı (nullable)
+}
+
 // SeasonParams yapısı, bir anime sezonu için gerekli parametreleri içerir.
 type SeasonParams struct {
 	Slug *string // Anime URL dostu adı (nullable)
@@ -0,0 +1,83 @@
+package player
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Command, kullanıcı tanımlı harici bir komutla oynatan Player implementasyonudur.
+// Yalnızca başlatma ve beklemeyi destekler; ilerleme bilgisi için ErrUnsupported döner.
+type Command struct {
+	argv []string
+	proc *process
+}
+
+// NewCommand, verilen komut şablonuyla bir oynatıcı oluşturur.
+// Argümanlardaki {url}, {title}, {subtitle} ve {start} (saniye) yer tutucuları oynatma sırasında doldurulur;
+// hiçbir argümanda {url} yoksa video URL'si sona eklenir.
+func NewCommand(argv []string) (*Command, error) {
+	if len(argv) == 0 || strings.TrimSpace(argv[0]) == "" {
+		return nil, errors.New("command oynatıcısı için player_command tanımlanmalı")
+	}
+	return &Command{argv: argv}, nil
+}
+
+// Name, oynatıcının adını döner.
+func (c *Command) Name() string { return BackendCommand }
+
+// Start, komutu yer tutucuları doldurarak başlatır.
+func (c *Command) Start(params Params) error {
+	subtitle := ""
+	if params.SubtitleUrl != nil {
+		subtitle = *params.SubtitleUrl
+	}
+	start := fmt.Sprintf("%.0f", params.Start)
+	replacer := strings.NewReplacer("{url}", params.Url, "{title}", params.Title, "{subtitle}", subtitle, "{start}", start)
+
+	hasURL := false
+	args := make([]string, 0, len(c.argv))
+	for _, arg := range c.argv[1:] {
+		if strings.Contains(arg, "{url}") {
+			hasURL = true
+		}
+		// Altyazı yoksa yalnızca altyazıdan oluşan argüman atlanır
+		if subtitle == "" && arg == "{subtitle}" {
+			continue
+		}
+		args = append(args, replacer.Replace(arg))
+	}
+	if !hasURL {
+		args = append(args, params.Url)
+	}
+
+	if _, err := exec.LookPath(c.argv[0]); err != nil {
+		return fmt.Errorf("%s sisteminizde bulunamadı", c.argv[0])
+	}
+
+	proc, err := startProcess(exec.Command(c.argv[0], args...))
+	if err != nil {
+		return err
+	}
+	c.proc = proc
+	return nil
+}
+
+// Running, komutun hâlâ çalışıp çalışmadığını döner.
+func (c *Command) Running() bool { return c.proc.running() }
+
+// Position, harici komutlar için desteklenmez.
+func (c *Command) Position() (float64, error) { return 0, ErrUnsupported }
+
+// Duration, harici komutlar için desteklenmez.
+func (c *Command) Duration() (float64, error) { return 0, ErrUnsupported }
+
+// Paused, harici komutlar için desteklenmez.
+func (c *Command) Paused() (bool, error) { return false, ErrUnsupported }
+
+// Wait, komut sonlanana kadar bekler.
+func (c *Command) Wait() error { return c.proc.wait() }
+
+// Quit, komutun sürecini sonlandırır.
+func (c *Command) Quit() error { return c.proc.kill() }
@@ -0,0 +1,333 @@
+package player
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/axrona/anitr-cli/internal/ipc"
+)
+
+// ErrIPCClosed, IPC bağlantısı kapandıktan sonra gönderilen komutlar için döner.
+var ErrIPCClosed = errors.New("mpv IPC bağlantısı kapalı")
+
+// ipcTimeout, bir komutun yanıtı için beklenecek en uzun süredir
+const ipcTimeout = 2 * time.Second
+
+// ipcMessage, mpv'nin satır satır gönderdiği JSON mesajlarıdır.
+// Komut yanıtları request_id, olaylar event alanı taşır.
+type ipcMessage struct {
+	RequestID *int64          `json:"request_id"`
+	Error     string          `json:"error"`
+	Data      json.RawMessage `json:"data"`
+	Event     string          `json:"event"`
+	Name      string          `json:"name"`
+	Reason    string          `json:"reason"`
+	Args      []string        `json:"args"`
+}
+
+// ipcResponse, bekleyen bir komuta iletilen yanıttır
+type ipcResponse struct {
+	data interface{}
+	err  error
+}
+
+// IPCClient, mpv ile uzun ömürlü bir JSON IPC bağlantısıdır.
+// Komutlar request_id ile eşleştirilir; aynı bağlantı üzerinden birden fazla
+// goroutine komut gönderebilir ve Subscribe ile olay akışını dinleyebilir.
+type IPCClient struct {
+	conn net.Conn
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan ipcResponse
+	subs    map[chan Event]struct{}
+	state   State
+	closed  bool
+
+	done chan struct{}
+}
+
+// DialIPC, verilen soket yolundaki mpv'ye bağlanır ve yanıtları okumaya başlar.
+func DialIPC(socketPath string) (*IPCClient, error) {
+	conn, err := ipc.ConnectToPipe(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	return newIPCClient(conn), nil
+}
+
+// newIPCClient, açık bir bağlantı üzerinde istemciyi başlatır
+func newIPCClient(conn net.Conn) *IPCClient {
+	c := &IPCClient{
+		conn:    conn,
+		pending: make(map[int64]chan ipcResponse),
+		subs:    make(map[chan Event]struct{}),
+		done:    make(chan struct{}),
+	}
+	go c.readLoop()
+	return c
+}
+
+// Done, bağlantı kapandığında kapanan kanalı döner.
+func (c *IPCClient) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close, bağlantıyı kapatır. Bekleyen komutlar ErrIPCClosed ile sonlanır.
+func (c *IPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// Command, mpv'ye bir komut gönderir ve yanıtın data alanını döner.
+// mpv "success" dışında bir hata bildirirse hata mesajı olarak döner.
+func (c *IPCClient) Command(args ...interface{}) (interface{}, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrIPCClosed
+	}
+	c.nextID++
+	id := c.nextID
+	ch := make(chan ipcResponse, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"command":    args,
+		"request_id": id,
+	})
+	if err != nil {
+		c.forget(id)
+		return nil, err
+	}
+
+	c.writeMu.Lock()
+	_, err = c.conn.Write(append(payload, '\n'))
+	c.writeMu.Unlock()
+	if err != nil {
+		c.forget(id)
+		return nil, fmt.Errorf("mpv komutu gönderilemedi: %w", err)
+	}
+
+	select {
+	case resp := <-ch:
+		return resp.data, resp.err
+	case <-time.After(ipcTimeout):
+		c.forget(id)
+		return nil, fmt.Errorf("mpv komutu zaman aşımına uğradı: %v", args)
+	}
+}
+
+// forget, yanıtı artık beklenmeyen komutu listeden çıkarır
+func (c *IPCClient) forget(id int64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// Observe, verilen özellikleri observe_property ile izlemeye alır.
+// Değişiklikler Subscribe ile alınan olay akışına düşer.
+func (c *IPCClient) Observe(names ...string) error {
+	for i, name := range names {
+		if _, err := c.Command("observe_property", i+1, name); err != nil {
+			return fmt.Errorf("%s izlenemedi: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// State, olaylardan derlenen son oynatma durumunu döner.
+func (c *IPCClient) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+// Subscribe, olay akışına yeni bir abone ekler.
+// Kanal bağlantı kapandığında ya da dönen iptal fonksiyonu çağrıldığında kapanır.
+// Yavaş aboneler olay kaçırabilir; güncel durum her olayda State ile birlikte gelir.
+func (c *IPCClient) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, 16)
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	c.subs[ch] = struct{}{}
+	c.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if _, ok := c.subs[ch]; ok {
+				delete(c.subs, ch)
+				close(ch)
+			}
+		})
+	}
+}
+
+// readLoop, bağlantıdan gelen satırları okuyup yanıtlara ve olaylara dağıtır
+func (c *IPCClient) readLoop() {
+	defer c.shutdown()
+
+	reader := bufio.NewReader(c.conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var msg ipcMessage
+			if json.Unmarshal(line, &msg) == nil {
+				c.dispatch(msg)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// dispatch, tek bir mesajı bekleyen komuta ya da abonelere iletir
+func (c *IPCClient) dispatch(msg ipcMessage) {
+	if msg.Event != "" {
+		c.handleEvent(msg)
+		return
+	}
+	if msg.RequestID == nil {
+		return
+	}
+
+	c.mu.Lock()
+	ch, ok := c.pending[*msg.RequestID]
+	delete(c.pending, *msg.RequestID)
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	var resp ipcResponse
+	if msg.Error != "" && msg.Error != "success" {
+		resp.err = errors.New(msg.Error)
+	} else if len(msg.Data) > 0 {
+		resp.err = json.Unmarshal(msg.Data, &resp.data)
+	}
+	ch <- resp
+}
+
+// handleEvent, mpv olayını duruma işler ve abonelere yayar
+func (c *IPCClient) handleEvent(msg ipcMessage) {
+	var ev Event
+
+	c.mu.Lock()
+	switch msg.Event {
+	case "property-change":
+		var v interface{}
+		if len(msg.Data) > 0 {
+			_ = json.Unmarshal(msg.Data, &v)
+		}
+		switch msg.Name {
+		case "time-pos":
+			f, ok := v.(float64)
+			if !ok {
+				c.mu.Unlock()
+				return
+			}
+			c.state.Position = f
+			ev.Type = EventPosition
+		case "duration":
+			f, ok := v.(float64)
+			if !ok {
+				c.mu.Unlock()
+				return
+			}
+			c.state.Duration = f
+			ev.Type = EventDuration
+		case "pause":
+			b, _ := v.(bool)
+			c.state.Paused = b
+			ev.Type = EventPause
+		case "playlist-pos":
+			// Liste bitince mpv -1 bildirir; son videonun durumu korunur
+			f, ok := v.(float64)
+			if !ok || f < 0 || int(f) == c.state.PlaylistPos {
+				c.mu.Unlock()
+				return
+			}
+			// Yeni videoya geçildi, videoya özel durum sıfırlanır
+			c.state = State{Paused: c.state.Paused, PlaylistPos: int(f)}
+			ev.Type = EventPlaylistPos
+		case "eof-reached":
+			b, _ := v.(bool)
+			if !b {
+				c.mu.Unlock()
+				return
+			}
+			c.state.EOF = true
+			ev.Type = EventEOF
+		default:
+			c.mu.Unlock()
+			return
+		}
+	case "client-message":
+		// script-message ile gönderilen mesajlar (tuş atamaları gibi)
+		if len(msg.Args) == 0 {
+			c.mu.Unlock()
+			return
+		}
+		ev.Type = EventMessage
+		ev.Message = msg.Args
+	case "end-file":
+		if msg.Reason != "eof" {
+			c.mu.Unlock()
+			return
+		}
+		c.state.EOF = true
+		ev.Type = EventEOF
+	default:
+		c.mu.Unlock()
+		return
+	}
+	ev.State = c.state
+	c.broadcast(ev)
+	c.mu.Unlock()
+}
+
+// broadcast, olayı abonelere engellemeden gönderir. c.mu tutulurken çağrılır.
+func (c *IPCClient) broadcast(ev Event) {
+	for ch := range c.subs {
+		select {
+		case ch <- ev:
+		default:
+			// Abone yetişemiyor; olay atlanır
+		}
+	}
+}
+
+// shutdown, bağlantı kapandığında bekleyen komutları ve aboneleri sonlandırır
+func (c *IPCClient) shutdown() {
+	_ = c.conn.Close()
+
+	c.mu.Lock()
+	c.closed = true
+	for id, ch := range c.pending {
+		ch <- ipcResponse{err: ErrIPCClosed}
+		delete(c.pending, id)
+	}
+	for ch := range c.subs {
+		close(ch)
+		delete(c.subs, ch)
+	}
+	c.mu.Unlock()
+
+	close(c.done)
+}
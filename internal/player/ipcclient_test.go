@@ -0,0 +1,158 @@
+package player
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeMPV, net.Pipe'ın diğer ucunda mpv gibi davranır.
+// Yanıtları ters sırada gönderir ve araya olay satırları ekler.
+func fakeMPV(t *testing.T, conn net.Conn) {
+	t.Helper()
+	go func() {
+		reader := bufio.NewReader(conn)
+		var reqs []map[string]interface{}
+		for len(reqs) < 2 {
+			line, err := reader.ReadBytes('\n')
+			if err != nil {
+				return
+			}
+			var req map[string]interface{}
+			if err := json.Unmarshal(line, &req); err != nil {
+				t.Errorf("geçersiz istek: %v", err)
+				return
+			}
+			reqs = append(reqs, req)
+		}
+
+		// İki satır tek yazımda gelir; istemci satır satır ayırmalı
+		out := `{"event":"property-change","id":1,"name":"time-pos","data":12.5}` + "\n" +
+			`{"request_id":` + jsonNum(reqs[1]["request_id"]) + `,"error":"property unavailable"}` + "\n"
+		out += `{"event":"property-change","id":3,"name":"pause","data":true}` + "\n" +
+			`{"request_id":` + jsonNum(reqs[0]["request_id"]) + `,"error":"success","data":1440.0}` + "\n"
+		_, _ = conn.Write([]byte(out))
+	}()
+}
+
+func jsonNum(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func TestIPCClientMultiplexesByRequestID(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	c := newIPCClient(client)
+	defer c.Close()
+
+	events, cancel := c.Subscribe()
+	defer cancel()
+
+	fakeMPV(t, server)
+
+	type result struct {
+		data interface{}
+		err  error
+	}
+	durCh := make(chan result, 1)
+	go func() {
+		data, err := c.Command("get_property", "duration")
+		durCh <- result{data, err}
+	}()
+	// İlk isteğin önce yazılmasını bekle
+	time.Sleep(50 * time.Millisecond)
+	_, posErr := c.Command("get_property", "time-pos")
+
+	dur := <-durCh
+	if dur.err != nil || dur.data != 1440.0 {
+		t.Fatalf("duration = %v, %v; beklenen 1440", dur.data, dur.err)
+	}
+	if posErr == nil || posErr.Error() != "property unavailable" {
+		t.Fatalf("time-pos hatası = %v; beklenen property unavailable", posErr)
+	}
+
+	var got []EventType
+	timeout := time.After(time.Second)
+	for len(got) < 2 {
+		select {
+		case ev := <-events:
+			got = append(got, ev.Type)
+		case <-timeout:
+			t.Fatalf("olaylar alınamadı: %v", got)
+		}
+	}
+	if got[0] != EventPosition || got[1] != EventPause {
+		t.Fatalf("olaylar = %v", got)
+	}
+
+	st := c.State()
+	if st.Position != 12.5 || !st.Paused {
+		t.Fatalf("durum = %+v", st)
+	}
+}
+
+func TestIPCClientClosesSubscribersOnDisconnect(t *testing.T) {
+	server, client := net.Pipe()
+	c := newIPCClient(client)
+
+	events, cancel := c.Subscribe()
+	defer cancel()
+
+	server.Close()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("bağlantı kapanınca kanal kapanmalı")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("abone kanalı kapanmadı")
+	}
+
+	if _, err := c.Command("get_property", "pause"); err != ErrIPCClosed {
+		t.Fatalf("err = %v; beklenen ErrIPCClosed", err)
+	}
+}
+
+func TestIPCClientPlaylistPosResetsEntryState(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	c := newIPCClient(client)
+	defer c.Close()
+
+	events, cancel := c.Subscribe()
+	defer cancel()
+
+	go func() {
+		_, _ = server.Write([]byte(
+			`{"event":"property-change","id":1,"name":"time-pos","data":1400}` + "\n" +
+				`{"event":"end-file","reason":"eof"}` + "\n" +
+				`{"event":"property-change","id":5,"name":"playlist-pos","data":1}` + "\n" +
+				`{"event":"property-change","id":5,"name":"playlist-pos","data":-1}` + "\n" +
+				`{"event":"property-change","id":1,"name":"time-pos","data":3}` + "\n"))
+	}()
+
+	want := []EventType{EventPosition, EventEOF, EventPlaylistPos, EventPosition}
+	for i, w := range want {
+		select {
+		case ev := <-events:
+			if ev.Type != w {
+				t.Fatalf("olay %d = %v; beklenen %v", i, ev.Type, w)
+			}
+			if ev.Type == EventPlaylistPos && (ev.State.PlaylistPos != 1 || ev.State.EOF || ev.State.Position != 0) {
+				t.Fatalf("liste geçişinde durum sıfırlanmadı: %+v", ev.State)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("olay %d alınamadı", i)
+		}
+	}
+
+	if st := c.State(); st.PlaylistPos != 1 || st.Position != 3 {
+		t.Fatalf("durum = %+v", st)
+	}
+}
@@ -4,300 +4,352 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"runtime"
-	"strings"
 	"time"
-
-	"github.com/axrona/anitr-cli/internal/ipc"
 )
 
-// MPVParams yapısı, MPV oynatıcı parametrelerini tutar.
-type MPVParams struct {
-	Url         string  // Oynatılacak video URL'si
-	SubtitleUrl *string // Altyazı URL'si (isteğe bağlı)
-	Title       string  // Video başlığı
+// MPV, mpv'yi JSON IPC üzerinden yöneten Player implementasyonudur.
+// IINA da mpv tabanlı olduğundan aynı yapı farklı bir binary ve seçenek önekiyle kullanılır.
+type MPV struct {
+	name       string   // Oynatıcı adı
+	binary     string   // Çalıştırılacak program
+	optPrefix  string   // mpv seçeneklerinin öneki ("--" ya da IINA için "--mpv-")
+	extraArgs  []string // Seçeneklerden önce eklenen argümanlar
+	socketPath string   // IPC soket yolu
+	proc       *process
+	ipc        *IPCClient // Oturum boyunca açık kalan IPC bağlantısı
 }
 
-// isMPVInstalled fonksiyonu, sistemde MPV oynatıcısının yüklü olup olmadığını kontrol eder.
-func isMPVInstalled() error {
-	mpvBinary := "mpv"
+// NewMPV, mpv oynatıcısını oluşturur.
+func NewMPV() *MPV {
+	binary := "mpv"
 	if runtime.GOOS == "windows" {
-		mpvBinary = "mpv.exe"
+		binary = "mpv.exe"
 	}
-	_, err := exec.LookPath(mpvBinary) // MPV'nin sistemde bulunup bulunmadığını kontrol eder
-	return err
+	return &MPV{name: BackendMPV, binary: binary, optPrefix: "--"}
 }
 
-// getMPVSocketPath platforma göre IPC yolunu döner
-func getMPVSocketPath() string {
-	if runtime.GOOS == "windows" {
-		// Windows named pipe yolu
-		return `\\.\pipe\anitr-cli-410`
-	}
-	// Linux/macOS unix socket
-	return filepath.Join(os.TempDir(), "anitr-cli-410.sock")
+// NewIINA, macOS'taki IINA oynatıcısını iina-cli üzerinden oluşturur.
+// mpv seçenekleri --mpv- önekiyle aktarılır; IPC mpv ile aynıdır.
+func NewIINA() *MPV {
+	return &MPV{name: BackendIINA, binary: "iina", optPrefix: "--mpv-", extraArgs: []string{"--no-stdin"}}
 }
 
-// getMPVBinary platforma göre mpv binary adını döner
-func getMPVBinary() string {
-	if runtime.GOOS == "windows" {
-		return "mpv.exe"
-	}
-	return "mpv"
+// Name, oynatıcının adını döner.
+func (m *MPV) Name() string { return m.name }
+
+// SocketPath, oynatıcının IPC soket yolunu döner.
+func (m *MPV) SocketPath() string { return m.socketPath }
+
+// Start, verilen parametrelerle oynatıcıyı başlatır ve IPC soketinin hazır olmasını bekler.
+func (m *MPV) Start(params Params) error {
+	return m.launch([]Params{params}, false)
 }
 
-// Play fonksiyonu, verilen parametrelerle MPV oynatıcıyı başlatır.
-func Play(params MPVParams) (*exec.Cmd, string, error) {
-	mpvSocketPath := getMPVSocketPath()
+// SupportsPlaylist, oynatma listesinin desteklenip desteklenmediğini döner.
+// IINA, dosyaya özel seçenek sözdizimini (--{ ... --}) desteklemez.
+func (m *MPV) SupportsPlaylist() bool {
+	return m.optPrefix == "--"
+}
 
-	// MPV'nin yüklü olup olmadığını kontrol et
-	if err := isMPVInstalled(); err != nil {
-		return nil, "", errors.New("mpv sisteminizde yüklü değil") // Yükleme hatası
+// StartPlaylist, videoları tek bir mpv içinde oynatma listesi olarak başlatır.
+// Her girdinin başlığı, altyazısı ve başlangıç konumu mpv'nin dosyaya özel
+// seçenekleriyle (--{ ... --}) verilir.
+func (m *MPV) StartPlaylist(items []Params) error {
+	if !m.SupportsPlaylist() {
+		return ErrUnsupported
 	}
-
-	// MPV başlatma komutunu oluştur
-	args := []string{
-		"--fullscreen", // Tam ekran başlat
-		"--save-position-on-quit",
-		fmt.Sprintf("--title=%s", params.Title),
-		fmt.Sprintf("--force-media-title=%s", params.Title),
-		"--idle=once", "--really-quiet", "--no-terminal",
-		fmt.Sprintf("--input-ipc-server=%s", mpvSocketPath),
+	if len(items) == 0 {
+		return fmt.Errorf("oynatma listesi boş")
 	}
+	return m.launch(items, true)
+}
 
-	// Platform bazlı user-agent ve referrer ayarı (isteğe bağlı)
-	if runtime.GOOS == "linux" {
-		args = append(args,
-			"--user-agent=Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 Chrome/137.0.0.0 Safari/537.36",
-			"--referrer=https://yeshi.eu.org/")
-	} else if runtime.GOOS == "windows" {
-		args = append(args,
-			"--user-agent=Mozilla/5.0 (Windows NT 10.0; Win64; x64)",
-			"--referrer=https://yeshi.eu.org/")
-	}
+// fileOptions, tek bir video için mpv seçeneklerini döner
+func fileOptions(params Params) []string {
+	opts := []string{fmt.Sprintf("force-media-title=%s", params.Title)}
 
 	// Eğer altyazı URL'si varsa, altyazı dosyasını ekle
 	if params.SubtitleUrl != nil && *params.SubtitleUrl != "" {
-		args = append(args, fmt.Sprintf("--sub-file=%s", *params.SubtitleUrl))
+		opts = append(opts, fmt.Sprintf("sub-file=%s", *params.SubtitleUrl))
 	}
 
-	// Video URL'sini ekle
-	args = append(args, params.Url)
-
-	mpvBinary := getMPVBinary()
-	cmd := exec.Command(mpvBinary, args...)
-	if err := cmd.Start(); err != nil {
-		return cmd, "", err // Başlatma hatası
+	// Kalınan yerden devam
+	if params.Start > 0 {
+		opts = append(opts, fmt.Sprintf("start=%.0f", params.Start))
 	}
+	return opts
+}
 
-	// MPV'nin IPC soketinin hazır olmasını bekle
-	maxRetries := 25
-	retryDelay := 300 * time.Millisecond
-	for i := 0; i < maxRetries; i++ {
-		time.Sleep(retryDelay)
-		conn, err := ipc.ConnectToPipe(mpvSocketPath)
-		if err == nil {
-			conn.Close()
-			return cmd, mpvSocketPath, nil
-		}
+// launch, oynatıcıyı başlatır ve IPC bağlantısını kurar.
+// playlist true ise her video kendi seçenek grubuyla listeye eklenir.
+func (m *MPV) launch(items []Params, playlist bool) error {
+	// Oynatıcının yüklü olup olmadığını kontrol et
+	if _, err := exec.LookPath(m.binary); err != nil {
+		return fmt.Errorf("%s sisteminizde yüklü değil", m.name) // Yükleme hatası
 	}
 
-	return cmd, "", errors.New("MPV socket hazır değil, başlatılamadı")
-}
+	socketPath, err := newSocketPath()
+	if err != nil {
+		return fmt.Errorf("IPC soket yolu oluşturulamadı: %w", err)
+	}
+	m.socketPath = socketPath
 
-// MPVSendCommand, MPV'ye bir komut gönderir ve yanıtını döner.
-func MPVSendCommand(ipcSocketPath string, command []interface{}) (interface{}, error) {
-	var lastErr error
-	maxRetries := 3
-	retryDelay := 100 * time.Millisecond
+	// mpv seçeneklerini oluştur
+	opts := []string{
+		"fullscreen", // Tam ekran başlat
+		"idle=once", "really-quiet", "no-terminal",
+		fmt.Sprintf("input-ipc-server=%s", m.socketPath),
+	}
 
-	// Komutun gönderilmesini birkaç kez dener
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			time.Sleep(retryDelay)
-		}
+	// Platform bazlı user-agent ve referrer ayarı (isteğe bağlı)
+	if runtime.GOOS == "linux" {
+		opts = append(opts,
+			"user-agent=Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 Chrome/137.0.0.0 Safari/537.36",
+			"referrer=https://yeshi.eu.org/")
+	} else if runtime.GOOS == "windows" {
+		opts = append(opts,
+			"user-agent=Mozilla/5.0 (Windows NT 10.0; Win64; x64)",
+			"referrer=https://yeshi.eu.org/")
+	}
 
-		conn, err := ipc.ConnectToPipe(ipcSocketPath)
-		if err != nil {
-			lastErr = err
-			continue
-		}
-		defer func() { _ = conn.Close() }()
+	// Tek videoda pencere başlığı ve video seçenekleri genel seçeneklerdir
+	if !playlist {
+		opts = append(opts, fmt.Sprintf("title=%s", items[0].Title))
+		opts = append(opts, fileOptions(items[0])...)
+	}
 
-		// Komutu JSON formatına dönüştür
-		commandStr, err := json.Marshal(map[string]interface{}{
-			"command": command,
-		})
-		if err != nil {
-			return nil, err
-		}
+	args := append([]string{}, m.extraArgs...)
+	for _, opt := range opts {
+		args = append(args, m.optPrefix+opt)
+	}
 
-		// Komutu sokete gönder
-		_, err = conn.Write(append(commandStr, '\n'))
-		if err != nil {
-			lastErr = err
-			continue
+	// Video URL'lerini ekle
+	if playlist {
+		for _, item := range items {
+			args = append(args, "--{")
+			for _, opt := range fileOptions(item) {
+				args = append(args, m.optPrefix+opt)
+			}
+			args = append(args, item.Url, "--}")
 		}
+	} else {
+		args = append(args, items[0].Url)
+	}
 
-		// Yanıtı oku
-		buf := make([]byte, 4096)
-		if deadline, ok := conn.(interface{ SetReadDeadline(time.Time) error }); ok {
-			deadline.SetReadDeadline(time.Now().Add(1 * time.Second))
-		}
+	proc, err := startProcess(exec.Command(m.binary, args...))
+	if err != nil {
+		return err // Başlatma hatası
+	}
+	m.proc = proc
 
-		n, err := conn.Read(buf)
+	// IPC soketinin hazır olmasını bekle ve kalıcı bağlantıyı kur
+	maxRetries := 25
+	retryDelay := 300 * time.Millisecond
+	for i := 0; i < maxRetries; i++ {
+		time.Sleep(retryDelay)
+		c, err := DialIPC(m.socketPath)
 		if err != nil {
-			lastErr = err
 			continue
 		}
-
-		// Yanıtı çözümle
-		var response map[string]interface{}
-		if err := json.Unmarshal(buf[:n], &response); err != nil {
-			lastErr = err
+		// Geçmiş ve Discord RPC'nin dinlediği özellikler
+		if err := c.Observe("time-pos", "duration", "pause", "eof-reached", "playlist-pos"); err != nil {
+			_ = c.Close()
 			continue
 		}
-
-		// Yanıt verisi varsa, döndür
-		if data, exists := response["data"]; exists {
-			return data, nil
-		}
-		return nil, nil
+		m.ipc = c
+		return nil
 	}
 
-	return nil, fmt.Errorf("command failed after %d attempts: %w", maxRetries, lastErr) // Komut hatası
+	_ = m.proc.kill()
+	removeSocket(m.socketPath)
+	return fmt.Errorf("%s soketi hazır değil, başlatılamadı", m.name)
 }
 
-// SeekMPV, MPV'yi belirli bir zaman noktasına kaydırır.
-func SeekMPV(ipcSocketPath string, time int) (interface{}, error) {
-	command := []interface{}{"seek", time, "absolute"} // Mutlak zaman noktasına kaydır
-	return MPVSendCommand(ipcSocketPath, command)
+// client, oynatıcının IPC bağlantısını döner
+func (m *MPV) client() (*IPCClient, error) {
+	if m.ipc == nil {
+		return nil, ErrIPCClosed
+	}
+	return m.ipc, nil
 }
 
-// GetMPVPausedStatus, MPV'nin duraklatılıp duraklatılmadığını kontrol eder.
-func GetMPVPausedStatus(ipcSocketPath string) (bool, error) {
-	status, err := MPVSendCommand(ipcSocketPath, []interface{}{"get_property", "pause"})
-	if err != nil || status == nil {
-		return false, err // Durum hatası
+// Running, oynatıcının açık olup olmadığını döner.
+// iina-cli gibi hemen çıkan başlatıcılar için IPC bağlantısına da bakılır.
+func (m *MPV) Running() bool {
+	if m.proc.running() {
+		return true
 	}
-
-	paused, ok := status.(bool)
-	if ok {
-		return paused, nil // Duraklatma durumu
+	if m.ipc == nil {
+		return false
 	}
-	return false, nil
-}
-
-// GetMPVPlaybackSpeed, MPV oynatma hızını döner.
-func GetMPVPlaybackSpeed(ipcSocketPath string) (float64, error) {
-	speed, err := MPVSendCommand(ipcSocketPath, []interface{}{"get_property", "speed"})
-	if err != nil || speed == nil {
-		return 0, err // Hız hatası
+	select {
+	case <-m.ipc.Done():
+		return false
+	default:
+		return true
 	}
+}
 
-	currentSpeed, ok := speed.(float64)
-	if ok {
-		return currentSpeed, nil // Oynatma hızı
-	}
+// Position, saniye cinsinden oynatma konumunu döner.
+func (m *MPV) Position() (float64, error) {
+	return m.floatProperty("time-pos")
+}
 
-	return 0, nil
+// Duration, saniye cinsinden video süresini döner.
+func (m *MPV) Duration() (float64, error) {
+	return m.floatProperty("duration")
 }
 
-// GetPercentageWatched, izlenen kısmın yüzdesini döner.
-func GetPercentageWatched(ipcSocketPath string) (float64, error) {
-	currentTime, err := MPVSendCommand(ipcSocketPath, []interface{}{"get_property", "time-pos"})
-	if err != nil || currentTime == nil {
-		return 0, err // Zaman hatası
+// Paused, oynatmanın duraklatılıp duraklatılmadığını döner.
+func (m *MPV) Paused() (bool, error) {
+	c, err := m.client()
+	if err != nil {
+		return false, err
 	}
-
-	duration, err := MPVSendCommand(ipcSocketPath, []interface{}{"get_property", "duration"})
-	if err != nil || duration == nil {
-		return 0, err // Süre hatası
+	val, err := c.Command("get_property", "pause")
+	if err != nil {
+		return false, err
 	}
+	paused, _ := val.(bool)
+	return paused, nil
+}
 
-	currTime, ok1 := currentTime.(float64)
-	dur, ok2 := duration.(float64)
-
-	if ok1 && ok2 && dur > 0 {
-		percentageWatched := (currTime / dur) * 100 // İzlenen yüzdesi
-		return percentageWatched, nil
+// State, olaylardan derlenen son oynatma durumunu döner.
+func (m *MPV) State() State {
+	if m.ipc == nil {
+		return State{}
 	}
-
-	return 0, nil
+	return m.ipc.State()
 }
 
-// PercentageWatched, verilen oynatma zamanı ve toplam süreye göre izlenen yüzdesi hesaplar.
-func PercentageWatched(playbackTime int, duration int) float64 {
-	if duration > 0 {
-		percentage := (float64(playbackTime) / float64(duration)) * 100
-		return percentage
+// Subscribe, mpv'nin konum, süre, duraklatma ve bitiş olaylarını döner.
+// Tüm aboneler aynı IPC bağlantısını ve observe_property akışını paylaşır.
+func (m *MPV) Subscribe() (<-chan Event, func()) {
+	c, err := m.client()
+	if err != nil {
+		ch := make(chan Event)
+		close(ch)
+		return ch, func() {}
 	}
-	return float64(0)
+	return c.Subscribe()
 }
 
-// HasActivePlayback, MPV'nin aktif bir oynatma durumu olup olmadığını kontrol eder.
-func HasActivePlayback(ipcSocketPath string) (bool, error) {
-	maxRetries := 3
-	var lastErr error
-
-	// Aktif oynatma durumu kontrolü birkaç kez denenir
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			time.Sleep(200 * time.Millisecond)
-		}
+// Command, mpv'ye oturumun IPC bağlantısı üzerinden bir komut gönderir.
+func (m *MPV) Command(args ...interface{}) (interface{}, error) {
+	c, err := m.client()
+	if err != nil {
+		return nil, err
+	}
+	return c.Command(args...)
+}
 
-		timePos, err := MPVSendCommand(ipcSocketPath, []interface{}{"get_property", "time-pos"})
-		if err != nil {
-			if strings.Contains(err.Error(), "property unavailable") {
-				return false, nil // Özellik mevcut değil
-			}
+// BindKey, mpv penceresinde bir tuşa komut atar.
+// "script-message <ad> <argümanlar>" biçimindeki komutlar olay akışına EventMessage olarak düşer.
+func (m *MPV) BindKey(key, command string) error {
+	_, err := m.Command("keybind", key, command)
+	return err
+}
 
-			if strings.Contains(err.Error(), "connect: connection refused") ||
-				strings.Contains(err.Error(), "connect: no such file or directory") {
-				lastErr = err
-				continue
-			}
+// ShowText, mpv ekranında kısa bir mesaj gösterir.
+func (m *MPV) ShowText(text string, duration time.Duration) error {
+	_, err := m.Command("show-text", text, duration.Milliseconds())
+	return err
+}
 
-			return false, fmt.Errorf("error getting time-pos: %w", err) // Zaman alınamadı
-		}
+// Chapter, videodaki bir bölüm işaretidir
+type Chapter struct {
+	Title string  `json:"title"`
+	Time  float64 `json:"time"` // Saniye cinsinden başlangıç
+}
 
-		if timePos != nil {
-			return true, nil // Oynatma aktif
-		}
+// Chapters, güncel videonun bölüm işaretlerini döner.
+func (m *MPV) Chapters() ([]Chapter, error) {
+	val, err := m.Command("get_property", "chapter-list")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	var chapters []Chapter
+	if err := json.Unmarshal(raw, &chapters); err != nil {
+		return nil, fmt.Errorf("chapter-list çözümlenemedi: %w", err)
+	}
+	return chapters, nil
+}
 
-		return false, nil
+// floatProperty, sayısal bir mpv özelliğini okur.
+func (m *MPV) floatProperty(name string) (float64, error) {
+	val, err := m.Command("get_property", name)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := val.(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s özelliği okunamadı", name)
 	}
+	return f, nil
+}
 
-	return false, fmt.Errorf("failed to check playback status: %w", lastErr) // Durum kontrolü başarısız
+// Wait, oynatıcı kapanana kadar bekler.
+func (m *MPV) Wait() error {
+	err := m.proc.wait()
+	// Başlatıcı süreç oynatıcıdan önce çıktıysa IPC bağlantısı kapanana kadar bekle
+	if err == nil && m.ipc != nil {
+		<-m.ipc.Done()
+	}
+	// Oturum bitti, soketi temizle
+	removeSocket(m.socketPath)
+	return err
 }
 
-// IsMPVRunning, MPV'nin çalışıp çalışmadığını kontrol eder.
-func IsMPVRunning(socketPath string) bool {
-	if socketPath == "" {
-		return false // Geçersiz soket yolu
+// Quit, oynatıcıyı IPC üzerinden kapatır; yanıt alınamazsa süreci sonlandırır.
+func (m *MPV) Quit() error {
+	if _, err := m.Command("quit"); err != nil && !errors.Is(err, ErrIPCClosed) {
+		return m.proc.kill()
 	}
+	return nil
+}
 
+// MPVSendCommand, MPV'ye tek seferlik bir bağlantı üzerinden komut gönderir ve yanıtını döner.
+// Oynatma oturumu sırasında MPV.Command ile kalıcı bağlantının kullanılması tercih edilmelidir.
+func MPVSendCommand(ipcSocketPath string, command []interface{}) (interface{}, error) {
+	var lastErr error
 	maxRetries := 3
+	retryDelay := 100 * time.Millisecond
+
+	// Komutun gönderilmesini birkaç kez dener
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		if attempt > 0 {
-			time.Sleep(200 * time.Millisecond)
+			time.Sleep(retryDelay)
 		}
 
-		conn, err := ipc.ConnectToPipe(socketPath)
+		c, err := DialIPC(ipcSocketPath)
 		if err != nil {
-			continue // Bağlantı hatası
+			lastErr = err
+			continue
 		}
-		defer conn.Close()
-
-		_, err = MPVSendCommand(socketPath, []interface{}{"get_property", "pid"})
-		if err == nil {
-			return true // MPV çalışıyor
+		data, err := c.Command(command...)
+		_ = c.Close()
+		if err != nil && !errors.Is(err, ErrIPCClosed) {
+			// mpv'nin kendi hata yanıtı; tekrar denemenin anlamı yok
+			return nil, err
 		}
-
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return data, nil
 	}
 
-	return false // MPV çalışmıyor
+	return nil, fmt.Errorf("command failed after %d attempts: %w", maxRetries, lastErr) // Komut hatası
+}
+
+// SeekMPV, MPV'yi belirli bir zaman noktasına kaydırır.
+func SeekMPV(ipcSocketPath string, time int) (interface{}, error) {
+	command := []interface{}{"seek", time, "absolute"} // Mutlak zaman noktasına kaydır
+	return MPVSendCommand(ipcSocketPath, command)
 }
@@ -0,0 +1,260 @@
+package player
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnsupported, oynatıcının istenen bilgiyi (konum, süre vb.) sağlayamadığını belirtir.
+// Geçmiş ve Discord RPC bu durumda ilerleme bilgisi olmadan çalışmaya devam eder.
+var ErrUnsupported = errors.New("oynatıcı bu özelliği desteklemiyor")
+
+// Params, oynatıcıya verilecek video bilgileridir.
+type Params struct {
+	Url         string  // Oynatılacak video URL'si
+	SubtitleUrl *string // Altyazı URL'si (isteğe bağlı)
+	Title       string  // Video başlığı
+	Start       float64 // Oynatmaya başlanacak konum (saniye, 0: baştan)
+}
+
+// Player, video oynatıcı arka uçlarının ortak arayüzüdür.
+// İlerleme bildiremeyen arka uçlar Position, Duration ve Paused için ErrUnsupported döner.
+type Player interface {
+	// Oynatıcının adını döner ("mpv", "vlc" gibi).
+	Name() string
+	// Oynatıcıyı verilen video ile başlatır.
+	Start(params Params) error
+	// Oynatıcının hâlâ açık olup olmadığını döner.
+	Running() bool
+	// Saniye cinsinden oynatma konumunu döner.
+	Position() (float64, error)
+	// Saniye cinsinden video süresini döner.
+	Duration() (float64, error)
+	// Oynatmanın duraklatılıp duraklatılmadığını döner.
+	Paused() (bool, error)
+	// Oynatıcı kapanana kadar bekler.
+	Wait() error
+	// Oynatıcıyı kapatır.
+	Quit() error
+}
+
+// State, oynatıcının son bilinen oynatma durumudur.
+type State struct {
+	Position float64 // Saniye cinsinden konum
+	Duration float64 // Saniye cinsinden süre
+	Paused   bool    // Duraklatıldı mı
+	EOF      bool    // Video sonuna ulaşıldı mı
+
+	PlaylistPos int // Oynatma listesindeki güncel videonun sıfırdan başlayan sırası
+}
+
+// EventType, oynatıcı olayının türüdür.
+type EventType int
+
+// Oynatıcı olay türleri
+const (
+	EventPosition    EventType = iota // Konum değişti
+	EventDuration                     // Süre öğrenildi ya da değişti
+	EventPause                        // Duraklatma durumu değişti
+	EventEOF                          // Video sonuna ulaşıldı
+	EventPlaylistPos                  // Oynatma listesinde başka bir videoya geçildi
+	EventMessage                      // Oynatıcıdan mesaj geldi (mpv script-message)
+)
+
+// Event, oynatıcı olayı ve olay anındaki durumdur.
+type Event struct {
+	Type    EventType
+	State   State
+	Message []string // EventMessage için mesaj argümanları
+}
+
+// Subscriber, olay akışı sağlayabilen oynatıcıların arayüzüdür.
+// Kanal oynatıcı kapandığında ya da iptal fonksiyonu çağrıldığında kapanır.
+type Subscriber interface {
+	Subscribe() (<-chan Event, func())
+}
+
+// Ended, oynatıcının videonun sonuna ulaşarak kapanıp kapanmadığını döner.
+// Kullanıcı videoyu yarıda kapattıysa ya da oynatıcı durum bildiremiyorsa false döner.
+func Ended(p Player) bool {
+	s, ok := p.(interface{ State() State })
+	if !ok {
+		return false
+	}
+	return s.State().EOF
+}
+
+// pollInterval, olay akışı sağlamayan oynatıcıların yoklanma aralığıdır
+const pollInterval = 2 * time.Second
+
+// Subscribe, oynatıcının olay akışını döner.
+// Oynatıcı kendi olaylarını yayınlıyorsa (mpv) onları kullanır, aksi halde
+// oynatıcıyı düzenli olarak yoklayarak aynı biçimde olay üretir. İlerleme
+// bildiremeyen oynatıcılarda kanal yalnızca oynatıcı kapandığında kapanır.
+func Subscribe(p Player) (<-chan Event, func()) {
+	if s, ok := p.(Subscriber); ok {
+		return s.Subscribe()
+	}
+
+	ch := make(chan Event, 16)
+	quit := make(chan struct{})
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		var last State
+		for {
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+			}
+			if !p.Running() {
+				return
+			}
+
+			pos, dur, err := Progress(p)
+			if err != nil {
+				continue
+			}
+			state := State{Position: pos, Duration: dur}
+			state.Paused, _ = p.Paused()
+
+			events := []EventType{EventPosition}
+			if state.Duration != last.Duration {
+				events = append(events, EventDuration)
+			}
+			if state.Paused != last.Paused {
+				events = append(events, EventPause)
+			}
+			last = state
+
+			for _, t := range events {
+				select {
+				case ch <- Event{Type: t, State: state}:
+				default:
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return ch, func() { once.Do(func() { close(quit) }) }
+}
+
+// PlaylistPlayer, birden fazla videoyu tek oturumda oynatma listesi olarak açabilen oynatıcıların arayüzüdür.
+// SupportsPlaylist false dönen arka uçlarda StartPlaylist ErrUnsupported döner.
+type PlaylistPlayer interface {
+	SupportsPlaylist() bool
+	StartPlaylist(items []Params) error
+}
+
+// SupportsPlaylist, config'teki oynatıcının oynatma listesi açabilip açamadığını döner.
+func SupportsPlaylist(cfg Config) bool {
+	pl, err := New(cfg)
+	if err != nil {
+		return false
+	}
+	pp, ok := pl.(PlaylistPlayer)
+	return ok && pp.SupportsPlaylist()
+}
+
+// Desteklenen oynatıcı arka uçları
+const (
+	BackendMPV     = "mpv"
+	BackendIINA    = "iina"
+	BackendVLC     = "vlc"
+	BackendCommand = "command"
+)
+
+// Config, kullanılacak oynatıcı arka ucunun ayarlarıdır.
+type Config struct {
+	Backend string   // "mpv" (varsayılan), "iina", "vlc" ya da "command"
+	Command []string // "command" arka ucu için komut ve argümanları; {url}, {title}, {subtitle} yer tutucuları desteklenir
+}
+
+// New, config'e göre bir oynatıcı oluşturur.
+func New(cfg Config) (Player, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case "", BackendMPV:
+		return NewMPV(), nil
+	case BackendIINA:
+		return NewIINA(), nil
+	case BackendVLC:
+		return NewVLC(), nil
+	case BackendCommand:
+		return NewCommand(cfg.Command)
+	}
+	return nil, fmt.Errorf("bilinmeyen oynatıcı: %s (desteklenenler: mpv, iina, vlc, command)", cfg.Backend)
+}
+
+// Progress, oynatıcının konumunu ve süresini birlikte döner.
+// Arka uç ilerleme bildiremiyorsa ErrUnsupported döner.
+func Progress(p Player) (position, duration float64, err error) {
+	position, err = p.Position()
+	if err != nil {
+		return 0, 0, err
+	}
+	duration, err = p.Duration()
+	if err != nil {
+		return 0, 0, err
+	}
+	return position, duration, nil
+}
+
+// process, başlatılan oynatıcı sürecini izler.
+// Wait birden fazla kez çağrılabilir; Running süreç çıkana kadar true döner.
+type process struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error
+}
+
+// startProcess, komutu başlatır ve çıkışını arka planda bekler.
+func startProcess(cmd *exec.Cmd) (*process, error) {
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	p := &process{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		p.err = cmd.Wait()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+// running, sürecin hâlâ çalışıp çalışmadığını döner.
+func (p *process) running() bool {
+	if p == nil {
+		return false
+	}
+	select {
+	case <-p.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// wait, süreç çıkana kadar bekler ve çıkış hatasını döner.
+func (p *process) wait() error {
+	if p == nil {
+		return errors.New("oynatıcı başlatılmadı")
+	}
+	<-p.done
+	return p.err
+}
+
+// kill, süreci sonlandırır.
+func (p *process) kill() error {
+	if !p.running() {
+		return nil
+	}
+	return p.cmd.Process.Kill()
+}
@@ -0,0 +1,94 @@
+package player
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/axrona/anitr-cli/internal/ipc"
+)
+
+// socketPrefix, anitr-cli'nin oluşturduğu IPC soketlerinin ortak önekidir
+const socketPrefix = "anitr-cli-"
+
+// randomHex, n baytlık rastgele bir değeri onaltılık olarak döner
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// socketDir, unix soketlerinin oluşturulacağı dizini döner.
+// Varsa kullanıcıya özel $XDG_RUNTIME_DIR, yoksa geçici dizin kullanılır.
+func socketDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return os.TempDir()
+}
+
+// newSocketPath, oynatma oturumuna özel bir IPC yolu döner.
+// Yol süreç kimliği ve rastgele bir son ek içerdiğinden aynı anda çalışan
+// birden fazla anitr-cli ya da eski bir oturumdan kalan soket ile çakışmaz.
+func newSocketPath() (string, error) {
+	suffix, err := randomHex(4)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s%d-%s", socketPrefix, os.Getpid(), suffix)
+
+	if runtime.GOOS == "windows" {
+		// Windows named pipe yolu; pipe'lar sunucu kapanınca kendiliğinden silinir
+		return `\\.\pipe\` + name, nil
+	}
+	// Linux/macOS unix socket
+	return filepath.Join(socketDir(), name+".sock"), nil
+}
+
+// removeSocket, oturum bittiğinde unix soket dosyasını siler
+func removeSocket(path string) {
+	if runtime.GOOS == "windows" || path == "" {
+		return
+	}
+	_ = os.Remove(path)
+}
+
+// SweepStaleSockets, çökmüş ya da kapanmış oturumlardan kalan ve artık
+// yanıt vermeyen anitr-cli soketlerini siler. Başlangıçta çağrılır.
+func SweepStaleSockets() {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	dirs := []string{socketDir()}
+	if tmp := os.TempDir(); tmp != dirs[0] {
+		// Önceki sürümlerin sabit soketi geçici dizinde kalmış olabilir
+		dirs = append(dirs, tmp)
+	}
+
+	for _, dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, socketPrefix+"*.sock"))
+		if err != nil {
+			continue
+		}
+		for _, path := range matches {
+			info, err := os.Lstat(path)
+			if err != nil || info.Mode()&os.ModeSocket == 0 {
+				continue
+			}
+			// Dinleyen bir oynatıcı varsa soket başka bir oturuma aittir
+			if conn, err := ipc.ConnectToPipe(path); err == nil {
+				conn.Close()
+				continue
+			}
+			_ = os.Remove(path)
+		}
+	}
+}
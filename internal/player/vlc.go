@@ -0,0 +1,195 @@
+package player
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// VLC, VLC'yi HTTP arayüzü üzerinden yöneten Player implementasyonudur.
+// Arayüz yalnızca 127.0.0.1 üzerinde, oturuma özel rastgele bir parola ile açılır.
+type VLC struct {
+	binary   string
+	addr     string // HTTP arayüzünün adresi (host:port)
+	password string
+	client   *http.Client
+	proc     *process
+
+	mu   sync.Mutex
+	last vlcStatus // Son okunan durum
+}
+
+// vlcStatus, requests/status.json yanıtının kullanılan alanlarıdır
+type vlcStatus struct {
+	Time   float64 `json:"time"`   // Saniye cinsinden konum
+	Length float64 `json:"length"` // Saniye cinsinden süre
+	State  string  `json:"state"`  // "playing", "paused" ya da "stopped"
+}
+
+// NewVLC, VLC oynatıcısını oluşturur.
+func NewVLC() *VLC {
+	binary := "vlc"
+	if runtime.GOOS == "windows" {
+		binary = "vlc.exe"
+	}
+	return &VLC{binary: binary, client: &http.Client{Timeout: 2 * time.Second}}
+}
+
+// Name, oynatıcının adını döner.
+func (v *VLC) Name() string { return BackendVLC }
+
+// freePort, yerel arayüzde boş bir TCP portu bulur
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// Start, VLC'yi HTTP arayüzü açık olarak başlatır ve arayüzün hazır olmasını bekler.
+func (v *VLC) Start(params Params) error {
+	if _, err := exec.LookPath(v.binary); err != nil {
+		return fmt.Errorf("vlc sisteminizde yüklü değil")
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return fmt.Errorf("vlc için port bulunamadı: %w", err)
+	}
+	v.password, err = randomHex(16)
+	if err != nil {
+		return err
+	}
+	v.addr = fmt.Sprintf("127.0.0.1:%d", port)
+
+	args := []string{
+		"--fullscreen",
+		"--play-and-exit",
+		"--extraintf=http",
+		"--http-host=127.0.0.1",
+		fmt.Sprintf("--http-port=%d", port),
+		fmt.Sprintf("--http-password=%s", v.password),
+		fmt.Sprintf("--meta-title=%s", params.Title),
+		"--http-referrer=https://yeshi.eu.org/",
+	}
+	if params.SubtitleUrl != nil && *params.SubtitleUrl != "" {
+		args = append(args, fmt.Sprintf("--input-slave=%s", *params.SubtitleUrl))
+	}
+	if params.Start > 0 {
+		args = append(args, fmt.Sprintf("--start-time=%.0f", params.Start))
+	}
+	args = append(args, params.Url)
+
+	v.proc, err = startProcess(exec.Command(v.binary, args...))
+	if err != nil {
+		return err
+	}
+
+	// HTTP arayüzünün hazır olmasını bekle
+	for i := 0; i < 25; i++ {
+		time.Sleep(300 * time.Millisecond)
+		if _, err := v.status(); err == nil {
+			return nil
+		}
+		if !v.proc.running() {
+			break
+		}
+	}
+
+	_ = v.proc.kill()
+	return fmt.Errorf("vlc HTTP arayüzü hazır değil, başlatılamadı")
+}
+
+// status, VLC'nin oynatma durumunu okur
+func (v *VLC) status() (*vlcStatus, error) {
+	req, err := http.NewRequest(http.MethodGet, "http://"+v.addr+"/requests/status.json", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth("", v.password)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vlc durum isteği başarısız: %s", resp.Status)
+	}
+
+	var st vlcStatus
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.last = st
+	v.mu.Unlock()
+	return &st, nil
+}
+
+// State, son okunan oynatma durumunu döner.
+// VLC saniye hassasiyetinde bildirdiğinden son birkaç saniye bitiş sayılır.
+func (v *VLC) State() State {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return State{
+		Position: v.last.Time,
+		Duration: v.last.Length,
+		Paused:   v.last.State == "paused",
+		EOF:      v.last.Length > 0 && v.last.Time >= v.last.Length-pollInterval.Seconds()-1,
+	}
+}
+
+// Running, VLC'nin açık olup olmadığını döner.
+func (v *VLC) Running() bool {
+	return v.proc.running()
+}
+
+// Position, saniye cinsinden oynatma konumunu döner.
+func (v *VLC) Position() (float64, error) {
+	st, err := v.status()
+	if err != nil {
+		return 0, err
+	}
+	return st.Time, nil
+}
+
+// Duration, saniye cinsinden video süresini döner.
+// Akış henüz yüklenmediyse VLC süreyi 0 bildirir.
+func (v *VLC) Duration() (float64, error) {
+	st, err := v.status()
+	if err != nil {
+		return 0, err
+	}
+	if st.Length <= 0 {
+		return 0, fmt.Errorf("vlc süre bilgisi henüz hazır değil")
+	}
+	return st.Length, nil
+}
+
+// Paused, oynatmanın duraklatılıp duraklatılmadığını döner.
+func (v *VLC) Paused() (bool, error) {
+	st, err := v.status()
+	if err != nil {
+		return false, err
+	}
+	return st.State == "paused", nil
+}
+
+// Wait, VLC kapanana kadar bekler.
+func (v *VLC) Wait() error {
+	return v.proc.wait()
+}
+
+// Quit, VLC'yi kapatır. HTTP arayüzünde kapatma komutu olmadığından süreç sonlandırılır.
+func (v *VLC) Quit() error {
+	return v.proc.kill()
+}
@@ -1,40 +1,49 @@
 package animecix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/axrona/anitr-cli/internal"
+	"github.com/axrona/anitr-cli/internal/cache"
+	"github.com/axrona/anitr-cli/internal/httpx"
 	"github.com/axrona/anitr-cli/internal/models"
+	"github.com/axrona/anitr-cli/internal/sources"
 	"github.com/axrona/anitr-cli/internal/utils"
 )
 
 type AnimeCix struct{}
 
+// sourceID, kaynağın kayıt defteri ve önbellekteki kimliğidir
+const sourceID = "animecix"
+
+// AnimeciX kaynağını kayıt defterine ekler
+func init() {
+	sources.Register(sources.Info{
+		ID:     sourceID,
+		Name:   "AnimeciX",
+		Order:  1,
+		Source: AnimeCix{},
+	})
+}
+
 // AnimeCix API için yapılandırma ayarları
 var configAnimecix = internal.Config{
 	BaseUrl:        "https://animecix.tv/",
 	AlternativeUrl: "https://mangacix.net/",
-	VideoPlayers:   []string{"tau-video.xyz", "sibnet"},
+	VideoPlayers:   []string{"https://tau-video.xyz", "sibnet"},
 	HttpHeaders:    map[string]string{"Accept": "application/json", "User-Agent": "Mozilla/5.0", "x-e-h": "=.a"},
 }
 
-// VideoURL, video URL'sinin etiket ve bağlantısını tutar
-type VideoURL struct {
-	Label string `json:"label"`
-	URL   string `json:"url"`
-}
-
-// VideoResponse, video URL'leri için gelen yanıtın yapısı
-type VideoResponse struct {
-	URLs []VideoURL `json:"urls"`
+// OverrideConfig, AnimeciX API adreslerini değiştirir ve eski ayarları geri yükleyen fonksiyonu döner.
+// Boş bırakılan alanlar mevcut değerlerini korur.
+func OverrideConfig(cfg internal.Config) (restore func()) {
+	return configAnimecix.Override(cfg)
 }
 
 // Source, AnimeCix kaynağının adını döner
@@ -43,13 +52,13 @@ func (a AnimeCix) Source() string {
 }
 
 // GetSearchData, verilen sorguya göre anime verilerini döner
-func (a AnimeCix) GetSearchData(query string) ([]models.Anime, error) {
+func (a AnimeCix) GetSearchData(ctx context.Context, query string) ([]models.Anime, error) {
 	// Türkçe karakterleri ASCII'ye dönüştür ve boşlukları "-" ile değiştir
 	normalizedQuery := utils.NormalizeTurkishToASCII(query)
 	normalizedQuery = strings.ReplaceAll(normalizedQuery, " ", "-")
 
 	// Anime arama verilerini al
-	data, err := FetchAnimeSearchData(normalizedQuery)
+	data, err := FetchAnimeSearchData(ctx, normalizedQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -58,38 +67,13 @@ func (a AnimeCix) GetSearchData(query string) ([]models.Anime, error) {
 
 	// Alınan verileri Anime modeline dönüştür
 	for _, item := range data {
-		id, ok := item["id"].(float64)
-		if !ok {
-			return nil, fmt.Errorf("id verisi beklenen formatta değil")
-		}
-		intId := int(id)
-		title, ok := item["name"].(string)
-		if !ok {
-			return nil, fmt.Errorf("title verisi beklenen formatta değil")
-		}
-
-		animeType, ok := item["type"].(string)
-		if !ok {
-			animeType = ""
-		}
-
-		titleType, ok := item["title_type"].(string)
-		if !ok {
-			titleType = ""
-		}
-
-		poster, ok := item["poster"].(string)
-		if !ok {
-			poster = ""
-		}
-
 		// Anime bilgilerini ekle
 		returnData = append(returnData, models.Anime{
-			ID:        &intId,
-			Title:     title,
-			Type:      &animeType,
-			TitleType: &titleType,
-			ImageURL:  poster,
+			ID:        item.ID,
+			Title:     *item.Name,
+			Type:      &item.Type,
+			TitleType: &item.TitleType,
+			ImageURL:  item.Poster,
 		})
 	}
 
@@ -97,46 +81,35 @@ func (a AnimeCix) GetSearchData(query string) ([]models.Anime, error) {
 }
 
 // GetAnimeByID, verilen ID'ye göre tek anime verisini döner
-func (a AnimeCix) GetAnimeByID(idstr string) (*models.Anime, error) {
+func (a AnimeCix) GetAnimeByID(ctx context.Context, idstr string) (*models.Anime, error) {
 	id, err := strconv.Atoi(idstr)
 	if err != nil {
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%ssecure/titles/%d?titleId=%d", configAnimecix.BaseUrl, id, id)
-	data, err := internal.GetJson(url, configAnimecix.HttpHeaders)
+	resp, err := fetchTitle(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("anime verisi alınamadı: %w", err)
 	}
 
-	dataMap, ok := data.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("data verisi beklenen formatta değil")
-	}
-
-	titleMap, ok := dataMap["title"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("'title' verisi yok veya beklenen formatta değil")
-	}
-
-	name, _ := titleMap["name"].(string)
-	animeType, _ := titleMap["type"].(string)
-	titleType, _ := titleMap["title_type"].(string)
-	poster, _ := titleMap["poster"].(string)
-
+	title := resp.Title
 	return &models.Anime{
 		ID:        &id,
-		Title:     name,
-		Type:      &animeType,
-		TitleType: &titleType,
-		ImageURL:  poster,
+		Title:     title.Name,
+		Type:      &title.Type,
+		TitleType: &title.TitleType,
+		ImageURL:  title.Poster,
 	}, nil
 }
 
 // GetSeasonsData, anime için sezon bilgilerini döner
-func (a AnimeCix) GetSeasonsData(params models.SeasonParams) ([]models.Season, error) {
+func (a AnimeCix) GetSeasonsData(ctx context.Context, params models.SeasonParams) ([]models.Season, error) {
+	if params.Id == nil {
+		return nil, fmt.Errorf("anime ID'si eksik")
+	}
+
 	// Sezon verilerini al
-	data, err := FetchAnimeSeasonsData(*params.Id)
+	data, err := FetchAnimeSeasonsData(ctx, *params.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -149,10 +122,43 @@ func (a AnimeCix) GetSeasonsData(params models.SeasonParams) ([]models.Season, e
 	}, nil
 }
 
+// IsMovie, başlık türüne göre animenin film olup olmadığını döner
+func (a AnimeCix) IsMovie(ctx context.Context, params models.SeasonParams) (bool, error) {
+	if params.Id == nil {
+		return false, fmt.Errorf("anime ID'si eksik")
+	}
+
+	anime, err := a.GetAnimeByID(ctx, strconv.Itoa(*params.Id))
+	if err != nil {
+		return false, err
+	}
+	return anime.TitleType != nil && strings.ToLower(*anime.TitleType) == "movie", nil
+}
+
+// GetSubtitlesData, bölüm ya da film için mevcut altyazıları döner
+func (a AnimeCix) GetSubtitlesData(ctx context.Context, params models.SubtitleParams) ([]models.Subtitle, error) {
+	if params.Id == nil {
+		return nil, fmt.Errorf("anime ID'si eksik")
+	}
+
+	if params.IsMovie != nil && *params.IsMovie {
+		return FetchMovieCaptions(ctx, *params.Id)
+	}
+
+	if params.SeasonIndex == nil || params.EpisodeIndex == nil {
+		return nil, fmt.Errorf("sezon veya bölüm indeksi eksik")
+	}
+	return FetchCaptions(ctx, *params.SeasonIndex, *params.EpisodeIndex, *params.Id)
+}
+
 // GetEpisodesData, sezon için bölüm bilgilerini döner
-func (a AnimeCix) GetEpisodesData(params models.EpisodeParams) ([]models.Episode, error) {
+func (a AnimeCix) GetEpisodesData(ctx context.Context, params models.EpisodeParams) ([]models.Episode, error) {
+	if params.SeasonID == nil {
+		return nil, fmt.Errorf("anime ID'si eksik")
+	}
+
 	// Bölüm verilerini al
-	episodesRaw, err := FetchAnimeEpisodesData(*params.SeasonID)
+	episodesRaw, err := FetchAnimeEpisodesData(ctx, *params.SeasonID)
 	if err != nil {
 		return nil, fmt.Errorf("bölüm verileri alınamadı: %w", err)
 	}
@@ -160,13 +166,11 @@ func (a AnimeCix) GetEpisodesData(params models.EpisodeParams) ([]models.Episode
 	var episodes []models.Episode
 	// Bölümleri modele dönüştür
 	for i, item := range episodesRaw {
-		title, _ := item["name"].(string)
-		url, _ := item["url"].(string)
 		episode := models.Episode{
-			ID:     url,
-			Title:  title,
+			ID:     item.URL,
+			Title:  item.Name,
 			Number: i + 1,
-			Extra:  map[string]interface{}{"season_num": item["season_num"]},
+			Extra:  map[string]interface{}{"season_num": item.SeasonNum},
 		}
 		episodes = append(episodes, episode)
 	}
@@ -175,73 +179,57 @@ func (a AnimeCix) GetEpisodesData(params models.EpisodeParams) ([]models.Episode
 }
 
 // GetWatchData, anime için izleme verilerini döner
-func (a AnimeCix) GetWatchData(req models.WatchParams) ([]models.Watch, error) {
+func (a AnimeCix) GetWatchData(ctx context.Context, req models.WatchParams) ([]models.Watch, error) {
 	// Verilerin eksik olup olmadığını kontrol et
-	if req.IsMovie == nil || req.Url == nil || req.Id == nil || req.Extra == nil {
-		return nil, fmt.Errorf("panic")
+	if req.IsMovie == nil || req.Id == nil || req.Extra == nil {
+		return nil, fmt.Errorf("film bilgisi, anime ID'si veya ekstra bilgiler eksik")
+	}
+	if !*req.IsMovie && req.Url == nil {
+		return nil, fmt.Errorf("bölüm URL'si eksik")
 	}
 
 	// Parametreleri al
 	var (
-		isMovie      bool                   = *req.IsMovie
-		url          string                 = *req.Url
-		id           int                    = *req.Id
-		Extra        map[string]interface{} = *req.Extra
-		seasonIndex  int                    = Extra["seasonIndex"].(int)
-		episodeIndex int                    = Extra["episodeIndex"].(int)
+		isMovie bool                   = *req.IsMovie
+		id      int                    = *req.Id
+		Extra   map[string]interface{} = *req.Extra
 	)
+	seasonIndex, _ := Extra["seasonIndex"].(int)
+	episodeIndex, _ := Extra["episodeIndex"].(int)
 
 	// Eğer filmse, film izleme verilerini al
 	if isMovie {
-		data, err := AnimeMovieWatchApiUrl(id)
+		data, err := AnimeMovieWatchApiUrl(ctx, id)
 		if err != nil {
 			return nil, fmt.Errorf("film verileri alınamadı: %w", err)
 		}
 
-		// Video akışlarını kontrol et
-		streams, ok := data["video_streams"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("video_streams verisi beklenen formatta değil")
-		}
-
 		var labels []string
 		var urls []string
 		// Her bir video akışını listele
-		for _, s := range streams {
-			item, ok := s.(map[string]interface{})
-			if !ok {
-				continue
-			}
-			label, _ := item["label"].(string)
-			url, _ := item["url"].(string)
-
-			labels = append(labels, label)
-			urls = append(urls, url)
-		}
-
-		var captionUrl *string
-		if c, ok := data["caption_url"].(string); ok {
-			captionUrl = &c
+		for _, stream := range data.Streams {
+			labels = append(labels, stream.Label)
+			urls = append(urls, stream.URL)
 		}
 
 		// İzleme verilerini döndür
 		watch := models.Watch{
 			Labels:    labels,
 			Urls:      urls,
-			TRCaption: captionUrl,
+			TRCaption: data.CaptionURL,
 		}
 
 		return []models.Watch{watch}, nil
 	}
 
 	// Bölüm izleme verilerini al
-	videoStreams, err := AnimeWatchApiUrl(url)
+	videoStreams, err := AnimeWatchApiUrl(ctx, *req.Url)
 	if err != nil {
 		return nil, fmt.Errorf("bölüm verileri alınamadı: %w", err)
 	}
 
 	// Altyazıyı al
-	captionUrl, err := FetchTRCaption(seasonIndex, episodeIndex, id)
+	captionUrl, err := FetchTRCaption(ctx, seasonIndex, episodeIndex, id)
 	if err != nil {
 		captionUrl = ""
 	}
@@ -250,8 +238,8 @@ func (a AnimeCix) GetWatchData(req models.WatchParams) ([]models.Watch, error) {
 	var labels []string
 	var urls []string
 	for _, entry := range videoStreams {
-		labels = append(labels, entry["label"])
-		urls = append(urls, entry["url"])
+		labels = append(labels, entry.Label)
+		urls = append(urls, entry.URL)
 	}
 
 	// İzleme verisini döndür
@@ -264,88 +252,92 @@ func (a AnimeCix) GetWatchData(req models.WatchParams) ([]models.Watch, error) {
 	return []models.Watch{watch}, nil
 }
 
-// FetchAnimeSearchData, anime arama verilerini alır
-func FetchAnimeSearchData(query string) ([]map[string]interface{}, error) {
+// FetchAnimeSearchData, anime arama verilerini önbellek üzerinden alır
+func FetchAnimeSearchData(ctx context.Context, query string) ([]SearchResult, error) {
+	key := cache.Key{Source: sourceID, Endpoint: "search/" + query}
+	return cache.Fetch(ctx, key, cache.SearchTTL, func(ctx context.Context) ([]SearchResult, error) {
+		return fetchAnimeSearchData(ctx, query)
+	})
+}
+
+// fetchAnimeSearchData, anime arama verilerini API'den alır
+func fetchAnimeSearchData(ctx context.Context, query string) ([]SearchResult, error) {
 	// Arama URL'sini oluştur
 	url := fmt.Sprintf("%ssecure/search/%s?type=&limit=20", configAnimecix.BaseUrl, query)
-	// JSON verisini al
-	data, err := internal.GetJson(url, configAnimecix.HttpHeaders)
-	if err != nil {
-		return nil, err
-	}
 
-	// Veriyi işleyerek gerekli alanları çıkart
-	m, ok := data.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("data verisi beklenen formatta değil")
-	}
-
-	resultsRaw, exists := m["results"]
-	if !exists {
-		return nil, fmt.Errorf("'results' verisi bulunamadı")
+	// JSON verisini al ve doğrula
+	var resp searchResponse
+	if err := httpx.GetJSON(ctx, url, configAnimecix.HttpHeaders, &resp); err != nil {
+		return nil, err
 	}
-
-	resultsSlice, ok := resultsRaw.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("'results' verisi beklenen formatta değil")
+	if err := resp.validate(); err != nil {
+		return nil, err
 	}
 
-	var parsed []map[string]interface{}
-	// Her bir sonucu işleyip döndür
-	for _, item := range resultsSlice {
-		itemMap, ok := item.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("'item' verisi beklenen formatta değil")
-		}
-
-		entry := map[string]interface{}{
-			"name":       itemMap["name"],
-			"id":         itemMap["id"],
-			"type":       itemMap["type"],
-			"title_type": itemMap["title_type"],
-			"poster":     itemMap["poster"],
-		}
+	return *resp.Results, nil
+}
 
-		parsed = append(parsed, entry)
-	}
+// FetchAnimeSeasonsData, anime için sezon verilerini önbellek üzerinden alır
+func FetchAnimeSeasonsData(ctx context.Context, id int) ([]int, error) {
+	key := cache.Key{Source: sourceID, Endpoint: fmt.Sprintf("seasons/%d", id)}
+	return cache.Fetch(ctx, key, cache.SeasonsTTL, func(ctx context.Context) ([]int, error) {
+		return fetchAnimeSeasonsData(ctx, id)
+	})
+}
 
-	return parsed, nil
+// FetchAnimeEpisodesData, anime için bölüm verilerini önbellek üzerinden alır.
+// Süresi dolmuş liste hemen döner ve arka planda yenilenir.
+func FetchAnimeEpisodesData(ctx context.Context, id int) ([]EpisodeVideo, error) {
+	key := cache.Key{Source: sourceID, Endpoint: fmt.Sprintf("episodes/%d", id)}
+	return cache.FetchStale(ctx, key, cache.EpisodesTTL, cache.EpisodesMaxStale, func(ctx context.Context) ([]EpisodeVideo, error) {
+		return fetchAnimeEpisodesData(ctx, id)
+	})
 }
 
-// FetchAnimeSeasonsData, anime için sezon verilerini alır
-func FetchAnimeSeasonsData(id int) ([]int, error) {
-	url := fmt.Sprintf("%ssecure/related-videos?episode=1&season=1&titleId=%d&videoId=637113", configAnimecix.AlternativeUrl, id)
-	data, err := internal.GetJson(url, configAnimecix.HttpHeaders)
+// fetchAnimeSeasonsData, anime için sezon verilerini API'den alır
+func fetchAnimeSeasonsData(ctx context.Context, id int) ([]int, error) {
+	resp, err := fetchRelatedVideos(ctx, id, 1)
 	if err != nil {
 		return nil, fmt.Errorf("sezon verileri alınamadı: %w", err)
 	}
+	return parseSeasons(resp)
+}
 
-	dataMap, ok := data.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("data beklenen formatta değil")
-	}
+// fetchTitle, secure/titles yanıtını alır ve doğrular
+func fetchTitle(ctx context.Context, id int) (*titleResponse, error) {
+	url := fmt.Sprintf("%ssecure/titles/%d?titleId=%d", configAnimecix.BaseUrl, id, id)
 
-	videosField, ok := dataMap["videos"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("'videos' verisi yok veya beklenen formatta değil")
+	var resp titleResponse
+	if err := httpx.GetJSON(ctx, url, configAnimecix.HttpHeaders, &resp); err != nil {
+		return nil, err
 	}
-
-	video, ok := videosField[0].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("'videos'[0] verisi beklenen formatta değil")
+	if err := resp.validate(); err != nil {
+		return nil, err
 	}
+	return &resp, nil
+}
+
+// fetchRelatedVideos, verilen sezonun (1'den başlayan) related-videos yanıtını döner
+func fetchRelatedVideos(ctx context.Context, id, season int) (*relatedVideosResponse, error) {
+	url := fmt.Sprintf("%ssecure/related-videos?episode=1&season=%d&titleId=%d&videoId=637113", configAnimecix.AlternativeUrl, season, id)
 
-	title, ok := video["title"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("'title' verisi yok veya beklenen formatta değil")
+	var resp relatedVideosResponse
+	if err := httpx.GetJSON(ctx, url, configAnimecix.HttpHeaders, &resp); err != nil {
+		return nil, err
+	}
+	if err := resp.validate(); err != nil {
+		return nil, err
 	}
+	return &resp, nil
+}
 
-	seasons, ok := title["seasons"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("'seasons' verisi yok veya beklenen formatta değil")
+// parseSeasons, related-videos yanıtından sıfırdan başlayan sezon indekslerini çıkarır
+func parseSeasons(resp *relatedVideosResponse) ([]int, error) {
+	count, err := resp.seasonCount()
+	if err != nil {
+		return nil, err
 	}
 
-	count := len(seasons)
 	indices := make([]int, count)
 	for i := range indices {
 		indices[i] = i
@@ -354,72 +346,101 @@ func FetchAnimeSeasonsData(id int) ([]int, error) {
 	return indices, nil
 }
 
-// FetchAnimeEpisodesData, anime için bölüm verilerini alır
-func FetchAnimeEpisodesData(id int) ([]map[string]interface{}, error) {
-	var episodes []map[string]interface{}
-	seenEpisodes := make(map[string]bool)
-	seasons, err := FetchAnimeSeasonsData(id)
+// parseSeasonEpisodes, related-videos yanıtından bir sezonun bölümlerini çıkarır
+func parseSeasonEpisodes(resp *relatedVideosResponse) ([]EpisodeVideo, error) {
+	if err := resp.validateEpisodes(); err != nil {
+		return nil, err
+	}
+
+	episodes := make([]EpisodeVideo, 0, len(*resp.Videos))
+	for _, video := range *resp.Videos {
+		episodes = append(episodes, EpisodeVideo{
+			Name:      *video.Name,
+			URL:       *video.URL,
+			SeasonNum: video.SeasonNum,
+		})
+	}
+
+	return episodes, nil
+}
+
+// fetchAnimeEpisodesData, anime için bölüm verilerini API'den alır.
+// İlk sezonun yanıtı sezon listesini de içerdiğinden ayrıca sezon isteği yapılmaz;
+// kalan sezonlar sources.Workers() sınırıyla paralel olarak alınır.
+func fetchAnimeEpisodesData(ctx context.Context, id int) ([]EpisodeVideo, error) {
+	first, err := fetchRelatedVideos(ctx, id, 1)
+	if err != nil {
+		return nil, fmt.Errorf("bölüm verileri alınamadı: %w", err)
+	}
+
+	seasons, err := parseSeasons(first)
 	if err != nil {
 		return nil, fmt.Errorf("sezon verileri alınamadı: %w", err)
 	}
 
-	// Her sezon için bölüm verilerini al
-	for _, seasonIndex := range seasons {
-		url := fmt.Sprintf("%ssecure/related-videos?episode=1&season=%d&titleId=%d&videoId=637113", configAnimecix.AlternativeUrl, seasonIndex+1, id)
-		data, err := internal.GetJson(url, configAnimecix.HttpHeaders)
-		if err != nil {
-			return nil, fmt.Errorf("bölüm verileri alınamadı: %w", err)
+	// Her sezon için bölüm verilerini al; sonuçlar sezon sırasıyla döner
+	perSeason, err := sources.FetchAll(ctx, len(seasons), func(ctx context.Context, i int) ([]EpisodeVideo, error) {
+		if seasons[i] == 0 {
+			return parseSeasonEpisodes(first)
 		}
 
-		dataMap, ok := data.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("data beklenen formatta değil")
+		resp, err := fetchRelatedVideos(ctx, id, seasons[i]+1)
+		if err != nil {
+			return nil, fmt.Errorf("sezon %d için bölüm verileri alınamadı: %w", seasons[i]+1, err)
 		}
+		return parseSeasonEpisodes(resp)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		videosRaw, ok := dataMap["videos"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("'videos' verisi yok veya beklenen formatta değil")
+	var episodes []EpisodeVideo
+	seenEpisodes := make(map[string]bool)
+	for _, seasonEpisodes := range perSeason {
+		for _, episode := range seasonEpisodes {
+			if seenEpisodes[episode.Name] {
+				continue
+			}
+			episodes = append(episodes, episode)
+			seenEpisodes[episode.Name] = true
 		}
+	}
 
-		// Her bir video için bölüm verilerini ekle
-		for _, video := range videosRaw {
-			video, ok := video.(map[string]interface{})
-
-			if !ok {
-				return nil, err
-			}
+	return episodes, nil
+}
 
-			name, ok := video["name"].(string)
-			if !ok {
-				return nil, fmt.Errorf("name verisi beklenen formatta değil")
-			}
+// fetchEmbedVideos, yönlendirme sonrası ulaşılan oynatıcı URL'sinden video akışlarını alır
+func fetchEmbedVideos(ctx context.Context, embedUrl *url.URL) ([]VideoURL, error) {
+	// URL'yi çözümleyip, verileri al
+	pathParts := strings.Split(embedUrl.Path, "/")
+	if len(pathParts) < 3 {
+		return nil, fmt.Errorf("path verisi beklenen formatta değil: %s", embedUrl.Path)
+	}
 
-			if !seenEpisodes[name] {
-				episodeUrl, ok := video["url"].(string)
+	embedID := pathParts[2]
+	vid := embedUrl.Query().Get("vid")
 
-				if !ok {
-					return nil, fmt.Errorf("url verisi beklenen formatta değil")
-				}
+	apiUrl := fmt.Sprintf("%s/api/video/%s?vid=%s", configAnimecix.VideoPlayers[0], embedID, vid)
 
-				seasonNum := video["season_num"]
-				episode := map[string]interface{}{"name": name, "url": episodeUrl, "season_num": seasonNum}
-				episodes = append(episodes, episode)
-				seenEpisodes[name] = true
-			}
-		}
+	var videoResp VideoResponse
+	if err := httpx.GetJSON(ctx, apiUrl, nil, &videoResp); err != nil {
+		return nil, fmt.Errorf("video verileri alınamadı: %w", err)
+	}
+	if err := videoResp.validate(); err != nil {
+		return nil, err
 	}
 
-	return episodes, nil
+	return *videoResp.URLs, nil
 }
 
 // AnimeWatchApiUrl, anime için izleme verilerini döner
-func AnimeWatchApiUrl(Url string) ([]map[string]string, error) {
+func AnimeWatchApiUrl(ctx context.Context, Url string) ([]VideoURL, error) {
 	watch_url := fmt.Sprintf("%s%s", configAnimecix.BaseUrl, Url)
-	resp, err := http.Get(watch_url)
+	resp, err := httpx.Get(ctx, watch_url, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	resp.Body.Close()
 
 	// 422 hatası alırsak, beklenen formatta veriler yok demektir
 	if resp.StatusCode == 422 {
@@ -427,245 +448,116 @@ func AnimeWatchApiUrl(Url string) ([]map[string]string, error) {
 	}
 
 	// Gelen URL'yi işle ve video verilerine ulaş
-	finalUrl := resp.Request.URL.String()
-	parsedUrl, err := url.Parse(finalUrl)
-	if err != nil {
-		return nil, err
-	}
+	return fetchEmbedVideos(ctx, resp.Request.URL)
+}
 
-	// URL'yi çözümleyip, verileri al
-	pathParts := strings.Split(parsedUrl.Path, "/")
-	if len(pathParts) < 3 {
-		return nil, fmt.Errorf("path verisi beklenen formatta değil")
+// parseCaptions, altyazı kayıtlarını altyazı modeline dönüştürür; URL'si olmayanları atlar
+func parseCaptions(captions []Caption) []models.Subtitle {
+	subtitles := make([]models.Subtitle, 0, len(captions))
+	for _, caption := range captions {
+		if caption.URL == "" {
+			continue
+		}
+		subtitles = append(subtitles, models.Subtitle{Language: caption.Language, Url: caption.URL})
 	}
 
-	embedID := pathParts[2]
-	queryParams := parsedUrl.Query()
-	vid := queryParams.Get("vid")
+	return subtitles
+}
 
-	apiUrl := fmt.Sprintf("https://%s/api/video/%s?vid=%s", configAnimecix.VideoPlayers[0], embedID, vid)
-	response, err := http.Get(apiUrl)
-	if err != nil {
-		return nil, fmt.Errorf("video verileri alınamadı: %w", err)
+// pickTRCaption, altyazılar içinden Türkçe olanı, yoksa ilkini seçer
+func pickTRCaption(subtitles []models.Subtitle) (string, error) {
+	for _, subtitle := range subtitles {
+		if subtitle.Language == "tr" {
+			return subtitle.Url, nil
+		}
 	}
 
-	defer response.Body.Close()
-
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("video verileri okunamadı: %w", err)
+	// Eğer Türkçe altyazı bulunmazsa ilk altyazıyı kullan
+	if len(subtitles) == 0 {
+		return "", fmt.Errorf("altyazı bulunamadı")
 	}
+	return subtitles[0].Url, nil
+}
 
-	var videoResp VideoResponse
-	err = json.Unmarshal(body, &videoResp)
+// FetchCaptions, bir bölüm için mevcut tüm altyazıları döner
+func FetchCaptions(ctx context.Context, seasonIndex, episodeIndex, id int) ([]models.Subtitle, error) {
+	resp, err := fetchRelatedVideos(ctx, id, seasonIndex+1)
 	if err != nil {
-		return nil, fmt.Errorf("video verileri ayrıştırılamadı: %w", err)
+		return nil, fmt.Errorf("altyazı verileri alınamadı: %w", err)
 	}
 
-	// Video URL'leri ve etiketlerini döndür
-	results := []map[string]string{}
-	for _, item := range videoResp.URLs {
-		entry := map[string]string{
-			"label": item.Label,
-			"url":   item.URL,
-		}
-
-		results = append(results, entry)
+	// İlgili bölümü al
+	videos := *resp.Videos
+	if episodeIndex < 0 || episodeIndex >= len(videos) {
+		return nil, internal.MissingField("related-videos", "videos[%d]", episodeIndex)
 	}
 
-	return results, nil
+	return parseCaptions(videos[episodeIndex].Captions), nil
 }
 
-// FetchTRCaption, Türkçe altyazıyı döner
-func FetchTRCaption(seasonIndex, episodeIndex, id int) (string, error) {
-	url := fmt.Sprintf("%ssecure/related-videos?episode=1&season=%d&titleId=%d&videoId=637113", configAnimecix.AlternativeUrl, seasonIndex+1, id)
-	data, err := internal.GetJson(url, configAnimecix.HttpHeaders)
+// FetchMovieCaptions, bir film için mevcut tüm altyazıları döner
+func FetchMovieCaptions(ctx context.Context, id int) ([]models.Subtitle, error) {
+	resp, err := fetchTitle(ctx, id)
 	if err != nil {
-		return "", fmt.Errorf("altyazı verileri alınamadı: %w", err)
-	}
-
-	// Gelen veriyi çözümle ve Türkçe altyazıyı bul
-	dataMap, ok := data.(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("data verisi beklenen formatta değil")
-	}
-
-	videosSlice, ok := dataMap["videos"].([]interface{})
-	if !ok {
-		return "", fmt.Errorf("'videos' verisi yok veya beklenen formatta değil")
-	}
-
-	// İlgili bölümü al
-	video, ok := videosSlice[episodeIndex].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("episode verisi yok veya beklenen formatta değil")
+		return nil, fmt.Errorf("altyazı verileri alınamadı: %w", err)
 	}
-
-	// Altyazıyı kontrol et
-	captions, ok := video["captions"].([]interface{})
-	if !ok {
-		return "", fmt.Errorf("'captions' verisi yok veya beklenen formatta değil")
+	if resp.Title.Videos == nil {
+		return nil, internal.MissingField("titles", "title.videos")
 	}
 
-	for _, caption := range captions {
-		caption, ok := caption.(map[string]interface{})
-		if !ok {
-			return "", fmt.Errorf("caption verisi yok veya beklenen formatta değil")
-		}
-
-		lang, ok := caption["language"].(string)
-		if ok && lang == "tr" {
-			return caption["url"].(string), nil
+	// Altyazısı olan ilk videoyu kullan
+	for _, video := range *resp.Title.Videos {
+		if subtitles := parseCaptions(video.Captions); len(subtitles) > 0 {
+			return subtitles, nil
 		}
 	}
 
-	// Eğer Türkçe altyazı bulunmazsa, bir hata döndür
-	if len(captions) == 0 {
-		return "", fmt.Errorf("altyazı bulunamadı")
-	}
-	caption0 := captions[0].(map[string]interface{})
-	return caption0["url"].(string), nil
+	return nil, fmt.Errorf("altyazı bulunamadı")
 }
 
-// AnimeMovieWatchApiUrl, film için video URL'lerini döner
-func AnimeMovieWatchApiUrl(id int) (map[string]interface{}, error) {
-	Url := fmt.Sprintf("%ssecure/titles/%d?titleId=%d", configAnimecix.BaseUrl, id, id)
-
-	data, err := internal.GetJson(Url, configAnimecix.HttpHeaders)
+// FetchTRCaption, Türkçe altyazıyı döner
+func FetchTRCaption(ctx context.Context, seasonIndex, episodeIndex, id int) (string, error) {
+	subtitles, err := FetchCaptions(ctx, seasonIndex, episodeIndex, id)
 	if err != nil {
-		return nil, fmt.Errorf("anime verisi alınamadı: %w", err)
-	}
-
-	dataMap, ok := data.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("data beklenen formatta değil")
+		return "", err
 	}
+	return pickTRCaption(subtitles)
+}
 
-	// Video verileriyle birlikte altyazıları da döndür
-	titleMap, ok := dataMap["title"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("'title' verisi yok veya beklenen formatta değil")
+// AnimeMovieWatchApiUrl, film için video akışlarını ve Türkçe altyazıyı döner
+func AnimeMovieWatchApiUrl(ctx context.Context, id int) (*MovieWatch, error) {
+	resp, err := fetchTitle(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("anime verisi alınamadı: %w", err)
 	}
-
-	videosRaw, ok := titleMap["videos"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("'videos' verisi yok veya beklenen formatta değil")
+	if err := resp.validateVideos(); err != nil {
+		return nil, err
 	}
 
-	for _, video := range videosRaw {
-		video, ok := video.(map[string]interface{})
-
-		if !ok {
-			return nil, fmt.Errorf("'videos' verisi yok veya beklenen formatta değil")
-		}
-
-		videoUrl, ok := video["url"].(string)
-
-		if !ok {
-			return nil, fmt.Errorf("'url' verisi yok veya beklenen formatta değil")
-		}
-
+	for _, video := range *resp.Title.Videos {
 		// Video URL'yi çözümle
-		client := &http.Client{}
-		req, err := http.NewRequest("GET", videoUrl, nil)
-		if err != nil {
-			return nil, fmt.Errorf("HTTP isteği oluşturulamadı: %w", err)
-		}
-
-		req.Header.Set("Accept", configAnimecix.HttpHeaders["Accept"])
-		req.Header.Set("User-Agent", configAnimecix.HttpHeaders["User-Agent"])
-		req.Header.Set("x-e-h", "=.a")
-
-		resp, err := client.Do(req)
+		videoResp, err := httpx.Get(ctx, *video.URL, configAnimecix.HttpHeaders)
 		if err != nil {
 			return nil, fmt.Errorf("video verileri alınamadı: %w", err)
 		}
 
-		resp.Body.Close()
+		videoResp.Body.Close()
 
 		// Alınan URL'yi işleyerek video verilerini döndür
-		finalUrl := resp.Request.URL.String()
-		parsedUrl, err := url.Parse(finalUrl)
+		streams, err := fetchEmbedVideos(ctx, videoResp.Request.URL)
 		if err != nil {
-			return nil, fmt.Errorf("URL ayrıştırma hatası: %w", err)
-		}
-
-		pathParts := strings.Split(parsedUrl.Path, "/")
-
-		if len(pathParts) < 3 {
-			log.Printf("path verisi beklenen formatta değil: %s", parsedUrl.Path)
+			log.Printf("%v", err)
 			continue
 		}
 
-		embedID := pathParts[2]
-		queryParams := parsedUrl.Query()
-		vid := queryParams.Get("vid")
-
-		// Video verilerini al
-		apiUrl := fmt.Sprintf("https://%s/api/video/%s?vid=%s", configAnimecix.VideoPlayers[0], embedID, vid)
-
-		response, err := http.Get(apiUrl)
-		if err != nil {
-			return nil, fmt.Errorf("video verileri alınamadı: %w", err)
-		}
-
-		defer response.Body.Close()
-
-		// JSON cevabını çözümle
-		respBody, err := io.ReadAll(response.Body)
-		if err != nil {
-			return nil, fmt.Errorf("video verileri okunamadı: %w", err)
-		}
-
-		var videoResp VideoResponse
-		err = json.Unmarshal(respBody, &videoResp)
-		if err != nil {
-			return nil, fmt.Errorf("video verileri ayrıştırılamadı: %w", err)
-		}
-
-		// Video URL'lerini listele
-		result := make(map[string]interface{})
-		streams := make([]interface{}, 0)
-		for _, item := range videoResp.URLs {
-			entry := map[string]interface{}{
-				"label": item.Label,
-				"url":   item.URL,
-			}
-
-			streams = append(streams, entry)
-		}
-
-		result["video_streams"] = streams
+		result := &MovieWatch{Streams: streams}
 
 		// Altyazı URL'sini ekle
-		captions, ok := video["captions"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("'captions' verisi yok veya beklenen formatta değil")
-		}
-
-		if len(captions) < 1 {
-			result["caption_url"] = nil
-			return result, nil
+		if captionUrl, err := pickTRCaption(parseCaptions(video.Captions)); err == nil {
+			result.CaptionURL = &captionUrl
 		}
 
-		for _, caption := range captions {
-			caption, ok := caption.(map[string]interface{})
-			if !ok {
-				return nil, fmt.Errorf("caption verisi beklenen formatta değil")
-			}
-
-			lang, ok := caption["language"].(string)
-			if ok && lang == "tr" {
-				result["caption_url"] = caption["url"]
-			} else {
-				if len(captions) == 0 {
-					return nil, fmt.Errorf("altyazı bulunamadı")
-				}
-				result["caption_url"] = captions[0].(map[string]interface{})["url"]
-			}
-
-			return result, nil
-		}
+		return result, nil
 	}
 
 	return nil, fmt.Errorf("video verileri alınamadı")
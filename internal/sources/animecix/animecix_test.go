@@ -0,0 +1,217 @@
+package animecix
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/axrona/anitr-cli/internal"
+	"github.com/axrona/anitr-cli/internal/cache"
+	"github.com/axrona/anitr-cli/internal/models"
+	"github.com/axrona/anitr-cli/internal/sources/sourcetest"
+)
+
+// newTestServer, AnimeciX'i testdata fixture'larını sunan yerel sunucuya yönlendirir
+func newTestServer(t *testing.T) *sourcetest.Server {
+	t.Helper()
+
+	srv := sourcetest.NewServer(t, "testdata")
+	srv.Upstream("/base", "https://animecix.tv")
+	srv.Upstream("/alt", "https://mangacix.net")
+	srv.Upstream("/player", "https://tau-video.xyz")
+
+	srv.Handle("/base/secure/search/sousou-no-frieren?type=&limit=20", "search.json")
+	srv.Handle("/base/secure/search/bozuk?type=&limit=20", "search_missing_id.json")
+	srv.Handle("/base/secure/titles/42?titleId=42", "title.json")
+	srv.Handle("/base/secure/titles/77?titleId=77", "title_movie.json")
+	srv.Handle("/alt/secure/related-videos?episode=1&season=1&titleId=42&videoId=637113", "related_s1.json")
+	srv.Handle("/alt/secure/related-videos?episode=1&season=2&titleId=42&videoId=637113", "related_s2.json")
+	srv.Handle("/player/api/video/ep1?vid=5", "video_episode.json")
+	srv.Handle("/player/api/video/mv9?vid=3", "video_movie.json")
+	srv.Redirect("/base/secure/videos/1001", "/embed/ep1?vid=5")
+	srv.Redirect("/base/secure/videos/9001", "/embed/mv9?vid=3")
+
+	t.Cleanup(OverrideConfig(internal.Config{
+		BaseUrl:        srv.URLFor("/base/"),
+		AlternativeUrl: srv.URLFor("/alt/"),
+		VideoPlayers:   []string{srv.URLFor("/player")},
+	}))
+
+	cache.SetEnabled(false)
+	t.Cleanup(func() { cache.SetEnabled(true) })
+
+	return srv
+}
+
+func TestGetSearchData(t *testing.T) {
+	newTestServer(t)
+
+	results, err := AnimeCix{}.GetSearchData(context.Background(), "sousou no frieren")
+	if err != nil {
+		t.Fatalf("GetSearchData: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("2 sonuç bekleniyordu, %d geldi", len(results))
+	}
+	if got := results[0]; *got.ID != 42 || got.Title != "Sousou no Frieren" || *got.TitleType != "anime" {
+		t.Errorf("beklenmeyen ilk sonuç: %+v", got)
+	}
+	if got := results[1]; *got.ID != 77 || *got.TitleType != "movie" || got.ImageURL != "https://images.example/frieren-movie.jpg" {
+		t.Errorf("beklenmeyen ikinci sonuç: %+v", got)
+	}
+}
+
+func TestGetSearchDataMissingField(t *testing.T) {
+	newTestServer(t)
+
+	_, err := AnimeCix{}.GetSearchData(context.Background(), "bozuk")
+
+	var fieldErr *internal.FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("FieldError bekleniyordu, %v geldi", err)
+	}
+	if fieldErr.Field != "results[1].id" {
+		t.Errorf("eksik alan results[1].id olmalıydı, %q geldi", fieldErr.Field)
+	}
+}
+
+func TestGetAnimeByIDAndIsMovie(t *testing.T) {
+	newTestServer(t)
+	ctx := context.Background()
+
+	anime, err := AnimeCix{}.GetAnimeByID(ctx, "42")
+	if err != nil {
+		t.Fatalf("GetAnimeByID: %v", err)
+	}
+	if anime.Title != "Sousou no Frieren" || *anime.ID != 42 {
+		t.Errorf("beklenmeyen anime: %+v", anime)
+	}
+
+	for id, want := range map[int]bool{42: false, 77: true} {
+		got, err := AnimeCix{}.IsMovie(ctx, models.SeasonParams{Id: &id})
+		if err != nil {
+			t.Fatalf("IsMovie(%d): %v", id, err)
+		}
+		if got != want {
+			t.Errorf("IsMovie(%d) = %v, %v bekleniyordu", id, got, want)
+		}
+	}
+}
+
+func TestGetSeasonsData(t *testing.T) {
+	newTestServer(t)
+
+	id := 42
+	seasons, err := AnimeCix{}.GetSeasonsData(context.Background(), models.SeasonParams{Id: &id})
+	if err != nil {
+		t.Fatalf("GetSeasonsData: %v", err)
+	}
+	if got := *seasons[0].Seasons; !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Errorf("sezon indeksleri [0 1] olmalıydı, %v geldi", got)
+	}
+}
+
+func TestGetEpisodesData(t *testing.T) {
+	newTestServer(t)
+
+	id := 42
+	episodes, err := AnimeCix{}.GetEpisodesData(context.Background(), models.EpisodeParams{SeasonID: &id})
+	if err != nil {
+		t.Fatalf("GetEpisodesData: %v", err)
+	}
+
+	want := []struct {
+		title  string
+		url    string
+		season int
+	}{
+		{"1. Sezon 1. Bölüm", "secure/videos/1001", 1},
+		{"1. Sezon 2. Bölüm", "secure/videos/1002", 1},
+		{"2. Sezon 1. Bölüm", "secure/videos/2001", 2},
+	}
+	if len(episodes) != len(want) {
+		t.Fatalf("%d bölüm bekleniyordu, %d geldi", len(want), len(episodes))
+	}
+	for i, w := range want {
+		ep := episodes[i]
+		if ep.Title != w.title || ep.ID != w.url || ep.Number != i+1 || ep.Extra["season_num"] != w.season {
+			t.Errorf("bölüm %d: beklenmeyen değer %+v", i, ep)
+		}
+	}
+}
+
+func TestGetSubtitlesData(t *testing.T) {
+	newTestServer(t)
+	ctx := context.Background()
+
+	id, season, episode := 42, 0, 0
+	subs, err := AnimeCix{}.GetSubtitlesData(ctx, models.SubtitleParams{Id: &id, SeasonIndex: &season, EpisodeIndex: &episode})
+	if err != nil {
+		t.Fatalf("GetSubtitlesData: %v", err)
+	}
+	want := []models.Subtitle{
+		{Language: "en", Url: "https://subs.example/s1e1-en.vtt"},
+		{Language: "tr", Url: "https://subs.example/s1e1-tr.vtt"},
+	}
+	if !reflect.DeepEqual(subs, want) {
+		t.Errorf("altyazılar %v olmalıydı, %v geldi", want, subs)
+	}
+
+	movieID, isMovie := 77, true
+	subs, err = AnimeCix{}.GetSubtitlesData(ctx, models.SubtitleParams{Id: &movieID, IsMovie: &isMovie})
+	if err != nil {
+		t.Fatalf("GetSubtitlesData (film): %v", err)
+	}
+	if len(subs) != 2 || subs[1].Url != "https://subs.example/movie-tr.vtt" {
+		t.Errorf("beklenmeyen film altyazıları: %v", subs)
+	}
+}
+
+func TestGetWatchDataEpisode(t *testing.T) {
+	newTestServer(t)
+
+	id, isMovie, url := 42, false, "secure/videos/1001"
+	watches, err := AnimeCix{}.GetWatchData(context.Background(), models.WatchParams{
+		Id:      &id,
+		IsMovie: &isMovie,
+		Url:     &url,
+		Extra:   &map[string]interface{}{"seasonIndex": 0, "episodeIndex": 0},
+	})
+	if err != nil {
+		t.Fatalf("GetWatchData: %v", err)
+	}
+
+	w := watches[0]
+	if !reflect.DeepEqual(w.Labels, []string{"720p", "1080p"}) {
+		t.Errorf("beklenmeyen etiketler: %v", w.Labels)
+	}
+	if w.Urls[1] != "https://cdn.example/s1e1-1080.mp4" {
+		t.Errorf("beklenmeyen URL: %v", w.Urls)
+	}
+	if w.TRCaption == nil || *w.TRCaption != "https://subs.example/s1e1-tr.vtt" {
+		t.Errorf("Türkçe altyazı seçilmedi: %v", w.TRCaption)
+	}
+}
+
+func TestGetWatchDataMovie(t *testing.T) {
+	newTestServer(t)
+
+	id, isMovie := 77, true
+	watches, err := AnimeCix{}.GetWatchData(context.Background(), models.WatchParams{
+		Id:      &id,
+		IsMovie: &isMovie,
+		Extra:   &map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatalf("GetWatchData: %v", err)
+	}
+
+	w := watches[0]
+	if !reflect.DeepEqual(w.Labels, []string{"1080p"}) || w.Urls[0] != "https://cdn.example/movie-1080.mp4" {
+		t.Errorf("beklenmeyen film akışları: %+v", w)
+	}
+	if w.TRCaption == nil || *w.TRCaption != "https://subs.example/movie-tr.vtt" {
+		t.Errorf("Türkçe altyazı seçilmedi: %v", w.TRCaption)
+	}
+}
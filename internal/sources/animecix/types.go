@@ -0,0 +1,176 @@
+package animecix
+
+import "github.com/axrona/anitr-cli/internal"
+
+// Bu dosya AnimeciX API yanıtlarının tiplerini içerir.
+// Zorunlu alanlar pointer olarak tanımlanır; validate metotları eksik alanları
+// alan yoluyla birlikte internal.FieldError olarak bildirir.
+
+// searchResponse, secure/search yanıtıdır
+type searchResponse struct {
+	Results *[]SearchResult `json:"results"`
+}
+
+// SearchResult, arama sonucundaki tek bir başlıktır
+type SearchResult struct {
+	ID        *int    `json:"id"`
+	Name      *string `json:"name"`
+	Type      string  `json:"type"`
+	TitleType string  `json:"title_type"`
+	Poster    string  `json:"poster"`
+}
+
+func (r *searchResponse) validate() error {
+	if r.Results == nil {
+		return internal.MissingField("search", "results")
+	}
+	for i, item := range *r.Results {
+		if item.ID == nil {
+			return internal.MissingField("search", "results[%d].id", i)
+		}
+		if item.Name == nil {
+			return internal.MissingField("search", "results[%d].name", i)
+		}
+	}
+	return nil
+}
+
+// titleResponse, secure/titles yanıtıdır
+type titleResponse struct {
+	Title *Title `json:"title"`
+}
+
+// Title, bir başlığın (dizi ya da film) bilgileridir
+type Title struct {
+	Name      string        `json:"name"`
+	Type      string        `json:"type"`
+	TitleType string        `json:"title_type"`
+	Poster    string        `json:"poster"`
+	Videos    *[]TitleVideo `json:"videos"`
+}
+
+// TitleVideo, film başlıklarında bulunan video kaydıdır
+type TitleVideo struct {
+	URL      *string   `json:"url"`
+	Captions []Caption `json:"captions"`
+}
+
+// Caption, bir videoya ait altyazı kaydıdır
+type Caption struct {
+	URL      string `json:"url"`
+	Language string `json:"language"`
+}
+
+func (r *titleResponse) validate() error {
+	if r.Title == nil {
+		return internal.MissingField("titles", "title")
+	}
+	return nil
+}
+
+// validateVideos, film videolarının varlığını ve URL alanlarını denetler
+func (r *titleResponse) validateVideos() error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	if r.Title.Videos == nil {
+		return internal.MissingField("titles", "title.videos")
+	}
+	for i, video := range *r.Title.Videos {
+		if video.URL == nil {
+			return internal.MissingField("titles", "title.videos[%d].url", i)
+		}
+	}
+	return nil
+}
+
+// relatedVideosResponse, secure/related-videos yanıtıdır
+type relatedVideosResponse struct {
+	Videos *[]RelatedVideo `json:"videos"`
+}
+
+// RelatedVideo, bir sezondaki tek bir bölümdür
+type RelatedVideo struct {
+	Name      *string       `json:"name"`
+	URL       *string       `json:"url"`
+	SeasonNum int           `json:"season_num"`
+	Captions  []Caption     `json:"captions"`
+	Title     *RelatedTitle `json:"title"`
+}
+
+// RelatedTitle, bölümün ait olduğu başlığın sezon bilgisidir
+type RelatedTitle struct {
+	Seasons *[]interface{} `json:"seasons"`
+}
+
+func (r *relatedVideosResponse) validate() error {
+	if r.Videos == nil {
+		return internal.MissingField("related-videos", "videos")
+	}
+	return nil
+}
+
+// validateEpisodes, bölüm listesi için gereken ad ve URL alanlarını denetler
+func (r *relatedVideosResponse) validateEpisodes() error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	for i, video := range *r.Videos {
+		if video.Name == nil {
+			return internal.MissingField("related-videos", "videos[%d].name", i)
+		}
+		if video.URL == nil {
+			return internal.MissingField("related-videos", "videos[%d].url", i)
+		}
+	}
+	return nil
+}
+
+// seasonCount, yanıttaki ilk bölümün başlık bilgisinden sezon sayısını döner
+func (r *relatedVideosResponse) seasonCount() (int, error) {
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+	if len(*r.Videos) == 0 {
+		return 0, internal.MissingField("related-videos", "videos[0]")
+	}
+	title := (*r.Videos)[0].Title
+	if title == nil {
+		return 0, internal.MissingField("related-videos", "videos[0].title")
+	}
+	if title.Seasons == nil {
+		return 0, internal.MissingField("related-videos", "videos[0].title.seasons")
+	}
+	return len(*title.Seasons), nil
+}
+
+// EpisodeVideo, önbelleğe alınan bölüm kaydıdır
+type EpisodeVideo struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	SeasonNum int    `json:"season_num"`
+}
+
+// VideoURL, video URL'sinin etiket ve bağlantısını tutar
+type VideoURL struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// VideoResponse, video URL'leri için gelen yanıtın yapısı
+type VideoResponse struct {
+	URLs *[]VideoURL `json:"urls"`
+}
+
+func (r *VideoResponse) validate() error {
+	if r.URLs == nil {
+		return internal.MissingField("api/video", "urls")
+	}
+	return nil
+}
+
+// MovieWatch, bir filmin video akışları ve Türkçe altyazısıdır
+type MovieWatch struct {
+	Streams    []VideoURL
+	CaptionURL *string
+}
@@ -1,16 +1,36 @@
 package openanime
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/axrona/anitr-cli/internal"
+	"github.com/axrona/anitr-cli/internal/cache"
+	"github.com/axrona/anitr-cli/internal/httpx"
 	"github.com/axrona/anitr-cli/internal/models"
+	"github.com/axrona/anitr-cli/internal/sources"
 	"github.com/axrona/anitr-cli/internal/utils"
 )
 
 type OpenAnime struct{}
 
+// sourceID, kaynağın kayıt defteri ve önbellekteki kimliğidir
+const sourceID = "openanime"
+
+// OpenAnime kaynağını kayıt defterine ekler
+func init() {
+	sources.Register(sources.Info{
+		ID:    sourceID,
+		Name:  "OpenAnime",
+		Order: 0,
+		Capabilities: sources.Capabilities{
+			Slug: true,
+		},
+		Source: OpenAnime{},
+	})
+}
+
 // OpenAnime API için yapılandırma ayarları
 var configOpenAnime = internal.Config{
 	BaseUrl:      "https://api.openani.me",                                                                                                                                                                                                                          // API'nin temel URL'si
@@ -18,54 +38,50 @@ var configOpenAnime = internal.Config{
 	HttpHeaders:  map[string]string{"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36", "Origin": "https://openani.me", "Referer": "https://openani.me", "Accept": "application/json"}, // HTTP başlıkları
 }
 
+// OverrideConfig, OpenAnime API adreslerini değiştirir ve eski ayarları geri yükleyen fonksiyonu döner.
+// Boş bırakılan alanlar mevcut değerlerini korur.
+func OverrideConfig(cfg internal.Config) (restore func()) {
+	return configOpenAnime.Override(cfg)
+}
+
 // Source, OpenAnime kaynağının adını döner
 func (o OpenAnime) Source() string {
 	return "OpenAnime"
 }
 
 // GetSearchData, verilen sorguya göre anime verilerini döner
-func (o OpenAnime) GetSearchData(query string) ([]models.Anime, error) {
+func (o OpenAnime) GetSearchData(ctx context.Context, query string) ([]models.Anime, error) {
 	// Türkçe karakterleri ASCII'ye dönüştür ve boşlukları "+" ile değiştir
 	normalizedQuery := utils.NormalizeTurkishToASCII(query)
 	normalizedQuery = strings.ReplaceAll(normalizedQuery, " ", "+")
 
+	key := cache.Key{Source: sourceID, Endpoint: "search/" + normalizedQuery}
+	return cache.Fetch(ctx, key, cache.SearchTTL, func(ctx context.Context) ([]models.Anime, error) {
+		return o.fetchSearchData(ctx, normalizedQuery)
+	})
+}
+
+// fetchSearchData, normalize edilmiş sorgu için arama sonuçlarını API'den alır
+func (o OpenAnime) fetchSearchData(ctx context.Context, normalizedQuery string) ([]models.Anime, error) {
 	// Arama URL'sini oluştur ve JSON verisini al
 	url := fmt.Sprintf("%s/anime/search?q=%s", configOpenAnime.BaseUrl, normalizedQuery)
-	data, err := internal.GetJson(url, configOpenAnime.HttpHeaders)
-	if err != nil {
+
+	var results []SearchResult
+	if err := httpx.GetJSON(ctx, url, configOpenAnime.HttpHeaders, &results); err != nil {
 		return nil, fmt.Errorf("arama verileri alınamadı: %w", err)
 	}
+	if err := validateSearch(results); err != nil {
+		return nil, err
+	}
 
 	var returnData []models.Anime
 	// Alınan verileri anime modeline dönüştür
-	for _, item := range data.([]interface{}) {
-		anime, ok := item.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("geçersiz anime veri formatı")
-		}
-
-		// Anime bilgilerini al
-		name, ok := anime["english"].(string)
-		if !ok {
-			name = ""
-		}
-
-		slug, ok := anime["slug"].(string)
-		if !ok {
-			slug = ""
-		}
-
-		poster, ok := anime["pictures"].(map[string]interface{})["avatar"].(string)
-		if !ok {
-			poster = ""
-		}
-
-		// Anime bilgilerini döndür
+	for _, item := range results {
 		returnData = append(returnData, models.Anime{
-			Slug:     &slug,
-			Title:    name,
-			Source:   "openanime",
-			ImageURL: poster,
+			Slug:     item.Slug,
+			Title:    item.English,
+			Source:   sourceID,
+			ImageURL: item.avatar(),
 		})
 	}
 
@@ -73,128 +89,160 @@ func (o OpenAnime) GetSearchData(query string) ([]models.Anime, error) {
 }
 
 // GetAnimeById, doğrudan slug üzerinden anime verilerini döner
-func (o OpenAnime) GetAnimeByID(slug string) (*models.Anime, error) {
-	url := fmt.Sprintf("%s/anime/%s", configOpenAnime.BaseUrl, slug)
-	data, err := internal.GetJson(url, configOpenAnime.HttpHeaders)
+func (o OpenAnime) GetAnimeByID(ctx context.Context, slug string) (*models.Anime, error) {
+	anime, err := o.fetchAnime(ctx, slug)
 	if err != nil {
 		return nil, fmt.Errorf("slug verileri alınamadı: %w", err)
 	}
 
-	animeData, ok := data.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("geçersiz anime veri formatı")
-	}
+	return &models.Anime{
+		Slug:     &slug,
+		Title:    anime.English,
+		Source:   sourceID,
+		ImageURL: anime.avatar(),
+	}, nil
+}
 
-	name, ok := animeData["english"].(string)
-	if !ok {
-		name = ""
+// fetchAnime, anime/<slug> yanıtını alır
+func (o OpenAnime) fetchAnime(ctx context.Context, slug string) (*animeResponse, error) {
+	url := fmt.Sprintf("%s/anime/%s", configOpenAnime.BaseUrl, slug)
+
+	var anime animeResponse
+	if err := httpx.GetJSON(ctx, url, configOpenAnime.HttpHeaders, &anime); err != nil {
+		return nil, err
 	}
+	return &anime, nil
+}
 
-	poster, ok := animeData["pictures"].(map[string]interface{})["avatar"].(string)
-	if !ok {
-		poster = ""
+// GetSeasonsData, anime için sezon verilerini önbellek üzerinden döner
+func (o OpenAnime) GetSeasonsData(ctx context.Context, params models.SeasonParams) ([]models.Season, error) {
+	if params.Slug == nil {
+		return nil, fmt.Errorf("slug eksik")
 	}
 
-	return &models.Anime{
-		Slug:     &slug,
-		Title:    name,
-		Source:   "openanime",
-		ImageURL: poster,
-	}, nil
+	key := cache.Key{Source: sourceID, Endpoint: "seasons/" + *params.Slug}
+	return cache.Fetch(ctx, key, cache.SeasonsTTL, func(ctx context.Context) ([]models.Season, error) {
+		return o.fetchSeasonsData(ctx, *params.Slug)
+	})
 }
 
-// GetSeasonsData, anime için sezon verilerini döner
-func (o OpenAnime) GetSeasonsData(params models.SeasonParams) ([]models.Season, error) {
-	// Sezon verilerini almak için URL'yi oluştur
-	url := fmt.Sprintf("%s/anime/%s", configOpenAnime.BaseUrl, *params.Slug)
-	data, err := internal.GetJson(url, configOpenAnime.HttpHeaders)
+// fetchSeasonsData, anime için sezon verilerini API'den alır
+func (o OpenAnime) fetchSeasonsData(ctx context.Context, slug string) ([]models.Season, error) {
+	anime, err := o.fetchAnime(ctx, slug)
 	if err != nil {
 		return nil, fmt.Errorf("sezon verileri alınamadı: %w", err)
 	}
-
-	// Sezon bilgilerini işleyip döndür
-	seasonData, ok := data.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("sezon verisi beklenen formatta değil")
+	if err := anime.validateSeasons(); err != nil {
+		return nil, err
 	}
 
 	// Sezon sayısını al (Varsa)
-	seasonCount, ok := seasonData["numberOfSeasons"].(float64)
-	if !ok {
-		seasonCount = 1
+	seasonCount := 1
+	if anime.NumberOfSeasons != nil {
+		seasonCount = int(*anime.NumberOfSeasons)
 	}
 
-	contentType := seasonData["type"].(string)
+	contentType := *anime.Type
 	isMovie := strings.ToLower(contentType) == "movie"
 
 	// Sezon bilgilerini döndür
 	return []models.Season{
 		{
-			Seasons: &[]int{int(seasonCount)},
+			Seasons: &[]int{seasonCount},
 			Type:    &contentType,
 			IsMovie: &isMovie,
 		},
 	}, nil
 }
 
-// GetEpisodesData, sezon için bölüm verilerini döner
-func (o OpenAnime) GetEpisodesData(params models.EpisodeParams) ([]models.Episode, error) {
+// IsMovie, sezon verisindeki içerik tipine göre animenin film olup olmadığını döner
+func (o OpenAnime) IsMovie(ctx context.Context, params models.SeasonParams) (bool, error) {
+	seasonData, err := o.GetSeasonsData(ctx, params)
+	if err != nil {
+		return false, err
+	}
+	if len(seasonData) == 0 || seasonData[0].IsMovie == nil {
+		return false, fmt.Errorf("içerik tipi bulunamadı")
+	}
+	return *seasonData[0].IsMovie, nil
+}
+
+// GetEpisodesData, sezon için bölüm verilerini önbellek üzerinden döner.
+// Süresi dolmuş liste hemen döner ve arka planda yenilenir.
+func (o OpenAnime) GetEpisodesData(ctx context.Context, params models.EpisodeParams) ([]models.Episode, error) {
+	if params.Slug == nil {
+		return nil, fmt.Errorf("slug eksik")
+	}
+
+	key := cache.Key{Source: sourceID, Endpoint: "episodes/" + *params.Slug}
+	return cache.FetchStale(ctx, key, cache.EpisodesTTL, cache.EpisodesMaxStale, func(ctx context.Context) ([]models.Episode, error) {
+		return o.fetchEpisodesData(ctx, params)
+	})
+}
+
+// fetchEpisodesData, tüm sezonların bölüm verilerini API'den alır
+func (o OpenAnime) fetchEpisodesData(ctx context.Context, params models.EpisodeParams) ([]models.Episode, error) {
 	// Sezon verilerini al
-	seasonData, err := o.GetSeasonsData(models.SeasonParams{Slug: params.Slug})
+	seasonData, err := o.GetSeasonsData(ctx, models.SeasonParams{Slug: params.Slug})
 	if err != nil {
 		return nil, fmt.Errorf("sezon bilgisi alınamadı: %w", err)
 	}
 
-	// Bölüm verilerini al
-	var episodes []models.Episode
 	seasondata := *seasonData[0].Seasons
 	seasonCount := int(seasondata[0])
 
-	// Her bir sezon için bölüm verilerini al
-	for season := 1; season <= seasonCount; season++ {
-		url := fmt.Sprintf("%s/anime/%s/season/%d", configOpenAnime.BaseUrl, *params.Slug, season)
-		data, err := internal.GetJson(url, configOpenAnime.HttpHeaders)
-		if err != nil {
-			return nil, fmt.Errorf("sezon %d için bölüm verileri alınamadı: %w", season, err)
-		}
+	// Sezonların bölüm verilerini paralel al; sonuçlar sezon sırasıyla döner
+	perSeason, err := sources.FetchAll(ctx, seasonCount, func(ctx context.Context, i int) ([]models.Episode, error) {
+		return o.fetchSeasonEpisodes(ctx, *params.Slug, i+1)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		seasonInfo, ok := data.(map[string]interface{})["season"].(map[string]interface{})
-		if !ok {
-			continue
-		}
+	var episodes []models.Episode
+	for _, seasonEpisodes := range perSeason {
+		episodes = append(episodes, seasonEpisodes...)
+	}
 
-		// Bölüm verilerini işleyip listele
-		episodesRaw, ok := seasonInfo["episodes"].([]interface{})
-		if !ok {
-			continue
-		}
+	return episodes, nil
+}
 
-		// Her bir bölümü ekle
-		for _, episodeRaw := range episodesRaw {
-			episode, ok := episodeRaw.(map[string]interface{})
-			if !ok {
-				continue
-			}
-
-			episodeNumber, _ := episode["episodeNumber"].(float64)
-			seasonNumber, _ := seasonInfo["season_number"].(float64)
-			name := fmt.Sprintf("%d. Sezon, %d. Bölüm", int(seasonNumber), int(episodeNumber))
-
-			episodes = append(episodes, models.Episode{
-				Title:  name,
-				Number: int(episodeNumber),
-				Extra: map[string]interface{}{
-					"season_num": seasonNumber,
-				},
-			})
-		}
+// fetchSeasonEpisodes, tek bir sezonun (1'den başlayan) bölüm verilerini API'den alır
+func (o OpenAnime) fetchSeasonEpisodes(ctx context.Context, slug string, season int) ([]models.Episode, error) {
+	url := fmt.Sprintf("%s/anime/%s/season/%d", configOpenAnime.BaseUrl, slug, season)
+
+	var resp seasonResponse
+	if err := httpx.GetJSON(ctx, url, configOpenAnime.HttpHeaders, &resp); err != nil {
+		return nil, fmt.Errorf("sezon %d için bölüm verileri alınamadı: %w", season, err)
+	}
+	if err := resp.validate(); err != nil {
+		return nil, err
+	}
+	if resp.Season == nil {
+		return nil, nil
+	}
+
+	var episodes []models.Episode
+	// Her bir bölümü ekle
+	seasonNumber := resp.Season.SeasonNumber
+	for _, episode := range resp.Season.Episodes {
+		episodeNumber := *episode.EpisodeNumber
+		name := fmt.Sprintf("%d. Sezon, %d. Bölüm", int(seasonNumber), int(episodeNumber))
+
+		episodes = append(episodes, models.Episode{
+			Title:  name,
+			Number: int(episodeNumber),
+			Extra: map[string]interface{}{
+				"season_num": seasonNumber,
+			},
+		})
 	}
 
 	return episodes, nil
 }
 
 // GetFansubsData, fansub verilerini döner
-func (o OpenAnime) GetFansubsData(params models.FansubParams) ([]models.Fansub, error) {
+func (o OpenAnime) GetFansubsData(ctx context.Context, params models.FansubParams) ([]models.Fansub, error) {
 	// Gereksiz boş parametrelerin kontrolü
 	if params.Slug == nil || params.SeasonNum == nil || params.EpisodeNum == nil {
 		return nil, fmt.Errorf("slug, sezon numarası veya bölüm numarası eksik")
@@ -207,45 +255,27 @@ func (o OpenAnime) GetFansubsData(params models.FansubParams) ([]models.Fansub,
 
 	// Fansub verilerini almak için URL'yi oluştur
 	url := fmt.Sprintf("%s/anime/%s/season/%d/episode/%d", configOpenAnime.BaseUrl, slug, seasonNum, episodeNum)
-	data, err := internal.GetJson(url, configOpenAnime.HttpHeaders)
-	if err != nil {
+	var resp episodeResponse
+	if err := httpx.GetJSON(ctx, url, configOpenAnime.HttpHeaders, &resp); err != nil {
 		return nil, fmt.Errorf("fansub verileri alınamadı: %w", err)
 	}
-
-	// Raw fansub verilerini al
-	rawFansubs, ok := data.(map[string]interface{})["fansubs"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("fansubs verisi eksik veya hatalı")
+	if err := resp.validateFansubs(); err != nil {
+		return nil, err
 	}
 
 	// Geçerli fansubları ayıklayıp döndür
 	var fansubs []models.Fansub
-	for _, f := range rawFansubs {
-		fm, ok := f.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
+	for _, f := range *resp.Fansubs {
 		// 4K çözünürlükleri atla
-		is4K, ok := fm["is4K"].(bool)
-		if !ok || is4K {
+		if f.Is4K {
 			continue
 		}
 
-		id, idOK := fm["id"].(string)
-		name, nameOK := fm["name"].(string)
-		secureName, secureOK := fm["secureName"].(string)
-
-		// Fansub bilgileri eksikse hata döndür
-		if !idOK || !nameOK || !secureOK {
-			return nil, fmt.Errorf("fansub bilgisi eksik: %+v", fm)
-		}
-
 		// Geçerli fansub'u ekle
 		fansubs = append(fansubs, models.Fansub{
-			ID:         &id,
-			Name:       &name,
-			SecureName: &secureName,
+			ID:         f.ID,
+			Name:       f.Name,
+			SecureName: f.SecureName,
 		})
 	}
 
@@ -258,7 +288,7 @@ func (o OpenAnime) GetFansubsData(params models.FansubParams) ([]models.Fansub,
 }
 
 // GetWatchData, izleme verilerini döner
-func (o OpenAnime) GetWatchData(req models.WatchParams) ([]models.Watch, error) {
+func (o OpenAnime) GetWatchData(ctx context.Context, req models.WatchParams) ([]models.Watch, error) {
 	// Eksik parametre kontrolü
 	if req.Slug == nil || req.Extra == nil {
 		return nil, fmt.Errorf("slug veya ekstra bilgiler eksik")
@@ -282,50 +312,38 @@ func (o OpenAnime) GetWatchData(req models.WatchParams) ([]models.Watch, error)
 	baseURL := fmt.Sprintf("%s/anime/%s/season/%d/episode/%d", configOpenAnime.BaseUrl, slug, int(seasonNum), int(episodeNum))
 
 	// Fansub verilerini al
-	fansubs := extra["fansubs"].([]models.Fansub)
-	selectedFansubId := extra["selected_fansub_id"].(int)
+	fansubs, ok := extra["fansubs"].([]models.Fansub)
+	if !ok || len(fansubs) == 0 {
+		return nil, fmt.Errorf("fansub listesi eksik")
+	}
+	selectedFansubId, ok := extra["selected_fansub_id"].(int)
+	if !ok || selectedFansubId < 0 || selectedFansubId >= len(fansubs) {
+		return nil, fmt.Errorf("seçilen fansub indeksi geçersiz")
+	}
 
 	// Video URL'sini oluştur
 	videoURL := fmt.Sprintf("%s?fansub=%s", baseURL, *fansubs[selectedFansubId].ID)
-	data, err := internal.GetJson(videoURL, configOpenAnime.HttpHeaders)
-	if err != nil {
+	var resp episodeResponse
+	if err := httpx.GetJSON(ctx, videoURL, configOpenAnime.HttpHeaders, &resp); err != nil {
 		return nil, fmt.Errorf("video bağlantıları alınamadı: %w", err)
 	}
-
-	// Bölüm verilerini al
-	episodeData, ok := data.(map[string]interface{})["episodeData"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("episodeData eksik veya hatalı")
-	}
-
-	// Video dosyalarını al
-	files, ok := episodeData["files"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("video dosyaları bulunamadı veya geçersiz")
+	if err := resp.validateFiles(); err != nil {
+		return nil, err
 	}
 
 	var labels []string
 	var urls []string
 
 	// Her bir video dosyasını işleyip listele
-	for _, f := range files {
-		fileData, ok := f.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		// Dosya URL'sini ve çözünürlüğünü al
-		urlRaw, urlOK := fileData["file"].(string)
-		url := fmt.Sprintf("%s/animes/%s/%d/%s", configOpenAnime.VideoPlayers[0], slug, seasonNum, urlRaw)
-		resolutionVal, resOK := fileData["resolution"].(float64)
-
+	for _, f := range *resp.EpisodeData.Files {
 		// URL veya çözünürlük eksikse devam et
-		if !urlOK || !resOK {
+		if f.File == nil || f.Resolution == nil {
 			continue
 		}
 
 		// Çözünürlük etiketini ve URL'yi listeye ekle
-		labels = append(labels, fmt.Sprintf("%dp", int(resolutionVal)))
+		url := fmt.Sprintf("%s/animes/%s/%d/%s", configOpenAnime.VideoPlayers[0], slug, seasonNum, *f.File)
+		labels = append(labels, fmt.Sprintf("%dp", int(*f.Resolution)))
 		urls = append(urls, url)
 	}
 
@@ -0,0 +1,201 @@
+package openanime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/axrona/anitr-cli/internal"
+	"github.com/axrona/anitr-cli/internal/cache"
+	"github.com/axrona/anitr-cli/internal/models"
+	"github.com/axrona/anitr-cli/internal/sources/sourcetest"
+)
+
+// newTestServer, OpenAnime'yi testdata fixture'larını sunan yerel sunucuya yönlendirir
+func newTestServer(t *testing.T) *sourcetest.Server {
+	t.Helper()
+
+	srv := sourcetest.NewServer(t, "testdata")
+	srv.Upstream("/api", "https://api.openani.me")
+
+	srv.Handle("/api/anime/search?q=sousou+no+frieren", "search.json")
+	srv.Handle("/api/anime/search?q=bozuk", "search_missing_slug.json")
+	srv.Handle("/api/anime/sousou-no-frieren", "anime.json")
+	srv.Handle("/api/anime/bozuk", "anime_missing_type.json")
+	srv.Handle("/api/anime/sousou-no-frieren/season/1", "season_1.json")
+	srv.Handle("/api/anime/sousou-no-frieren/season/2", "season_2.json")
+	srv.Handle("/api/anime/sousou-no-frieren/season/1/episode/1", "episode.json")
+	srv.Handle("/api/anime/sousou-no-frieren/season/1/episode/1?fansub=12", "episode_fansub.json")
+
+	t.Cleanup(OverrideConfig(internal.Config{
+		BaseUrl:      srv.URLFor("/api"),
+		VideoPlayers: []string{srv.URLFor("/video")},
+	}))
+
+	cache.SetEnabled(false)
+	t.Cleanup(func() { cache.SetEnabled(true) })
+
+	return srv
+}
+
+func TestGetSearchData(t *testing.T) {
+	newTestServer(t)
+
+	results, err := OpenAnime{}.GetSearchData(context.Background(), "sousou no frieren")
+	if err != nil {
+		t.Fatalf("GetSearchData: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("2 sonuç bekleniyordu, %d geldi", len(results))
+	}
+	if got := results[0]; *got.Slug != "sousou-no-frieren" || got.ImageURL != "https://images.example/frieren.jpg" || got.Source != sourceID {
+		t.Errorf("beklenmeyen ilk sonuç: %+v", got)
+	}
+	if got := results[1]; *got.Slug != "sousou-no-frieren-specials" || got.ImageURL != "" {
+		t.Errorf("beklenmeyen ikinci sonuç: %+v", got)
+	}
+}
+
+func TestGetSearchDataMissingField(t *testing.T) {
+	newTestServer(t)
+
+	_, err := OpenAnime{}.GetSearchData(context.Background(), "bozuk")
+
+	var fieldErr *internal.FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("FieldError bekleniyordu, %v geldi", err)
+	}
+	if fieldErr.Field != "[1].slug" {
+		t.Errorf("eksik alan [1].slug olmalıydı, %q geldi", fieldErr.Field)
+	}
+}
+
+func TestGetAnimeByID(t *testing.T) {
+	newTestServer(t)
+
+	anime, err := OpenAnime{}.GetAnimeByID(context.Background(), "sousou-no-frieren")
+	if err != nil {
+		t.Fatalf("GetAnimeByID: %v", err)
+	}
+	if anime.Title != "Frieren: Beyond Journey's End" || *anime.Slug != "sousou-no-frieren" {
+		t.Errorf("beklenmeyen anime: %+v", anime)
+	}
+}
+
+func TestGetSeasonsData(t *testing.T) {
+	newTestServer(t)
+	ctx := context.Background()
+
+	slug := "sousou-no-frieren"
+	seasons, err := OpenAnime{}.GetSeasonsData(ctx, models.SeasonParams{Slug: &slug})
+	if err != nil {
+		t.Fatalf("GetSeasonsData: %v", err)
+	}
+	if got := *seasons[0].Seasons; !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("sezon sayısı [2] olmalıydı, %v geldi", got)
+	}
+	if *seasons[0].Type != "tv" || *seasons[0].IsMovie {
+		t.Errorf("beklenmeyen içerik tipi: %+v", seasons[0])
+	}
+
+	isMovie, err := OpenAnime{}.IsMovie(ctx, models.SeasonParams{Slug: &slug})
+	if err != nil || isMovie {
+		t.Errorf("IsMovie = %v, %v; false bekleniyordu", isMovie, err)
+	}
+}
+
+func TestGetSeasonsDataMissingField(t *testing.T) {
+	newTestServer(t)
+
+	slug := "bozuk"
+	_, err := OpenAnime{}.GetSeasonsData(context.Background(), models.SeasonParams{Slug: &slug})
+
+	var fieldErr *internal.FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("FieldError bekleniyordu, %v geldi", err)
+	}
+	if fieldErr.Field != "type" {
+		t.Errorf("eksik alan type olmalıydı, %q geldi", fieldErr.Field)
+	}
+}
+
+func TestGetEpisodesData(t *testing.T) {
+	newTestServer(t)
+
+	slug := "sousou-no-frieren"
+	episodes, err := OpenAnime{}.GetEpisodesData(context.Background(), models.EpisodeParams{Slug: &slug})
+	if err != nil {
+		t.Fatalf("GetEpisodesData: %v", err)
+	}
+
+	want := []struct {
+		title  string
+		number int
+		season float64
+	}{
+		{"1. Sezon, 1. Bölüm", 1, 1},
+		{"1. Sezon, 2. Bölüm", 2, 1},
+		{"2. Sezon, 1. Bölüm", 1, 2},
+	}
+	if len(episodes) != len(want) {
+		t.Fatalf("%d bölüm bekleniyordu, %d geldi", len(want), len(episodes))
+	}
+	for i, w := range want {
+		ep := episodes[i]
+		if ep.Title != w.title || ep.Number != w.number || ep.Extra["season_num"] != w.season {
+			t.Errorf("bölüm %d: beklenmeyen değer %+v", i, ep)
+		}
+	}
+}
+
+func TestGetFansubsData(t *testing.T) {
+	newTestServer(t)
+
+	slug, season, episode := "sousou-no-frieren", 1, 1
+	fansubs, err := OpenAnime{}.GetFansubsData(context.Background(), models.FansubParams{
+		Slug:       &slug,
+		SeasonNum:  &season,
+		EpisodeNum: &episode,
+	})
+	if err != nil {
+		t.Fatalf("GetFansubsData: %v", err)
+	}
+
+	// 4K kaydı atlanmalı
+	if len(fansubs) != 2 {
+		t.Fatalf("2 fansub bekleniyordu, %d geldi", len(fansubs))
+	}
+	if *fansubs[0].Name != "AnimeTR" || *fansubs[1].ID != "12" || *fansubs[1].SecureName != "frieren-fansub" {
+		t.Errorf("beklenmeyen fansublar: %+v, %+v", fansubs[0], fansubs[1])
+	}
+}
+
+func TestGetWatchData(t *testing.T) {
+	srv := newTestServer(t)
+
+	fansubID, fansubName, secureName := "12", "Frieren Fansub", "frieren-fansub"
+	slug := "sousou-no-frieren"
+	watches, err := OpenAnime{}.GetWatchData(context.Background(), models.WatchParams{
+		Slug: &slug,
+		Extra: &map[string]interface{}{
+			"season_num":         1,
+			"episode_num":        1,
+			"fansubs":            []models.Fansub{{}, {ID: &fansubID, Name: &fansubName, SecureName: &secureName}},
+			"selected_fansub_id": 1,
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetWatchData: %v", err)
+	}
+
+	// Çözünürlüğü ya da dosyası eksik kayıtlar atlanmalı
+	w := watches[0]
+	if !reflect.DeepEqual(w.Labels, []string{"720p", "1080p"}) {
+		t.Errorf("beklenmeyen etiketler: %v", w.Labels)
+	}
+	want := srv.URLFor("/video/animes/sousou-no-frieren/1/s1e1-1080.mp4")
+	if w.Urls[1] != want {
+		t.Errorf("URL %q olmalıydı, %q geldi", want, w.Urls[1])
+	}
+}
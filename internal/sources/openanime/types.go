@@ -0,0 +1,148 @@
+package openanime
+
+import "github.com/axrona/anitr-cli/internal"
+
+// Bu dosya OpenAnime API yanıtlarının tiplerini içerir.
+// Zorunlu alanlar pointer olarak tanımlanır; validate metotları eksik alanları
+// alan yoluyla birlikte internal.FieldError olarak bildirir.
+
+// Pictures, animenin görsel bağlantılarıdır
+type Pictures struct {
+	Avatar string `json:"avatar"`
+}
+
+// SearchResult, arama sonucundaki tek bir animedir
+type SearchResult struct {
+	English  string    `json:"english"`
+	Slug     *string   `json:"slug"`
+	Pictures *Pictures `json:"pictures"`
+}
+
+// avatar, varsa animenin poster URL'sini döner
+func (r SearchResult) avatar() string {
+	if r.Pictures == nil {
+		return ""
+	}
+	return r.Pictures.Avatar
+}
+
+// validateSearch, arama sonuçlarında slug alanının varlığını denetler
+func validateSearch(results []SearchResult) error {
+	for i, item := range results {
+		if item.Slug == nil {
+			return internal.MissingField("anime/search", "[%d].slug", i)
+		}
+	}
+	return nil
+}
+
+// animeResponse, anime/<slug> yanıtıdır
+type animeResponse struct {
+	English         string    `json:"english"`
+	Pictures        *Pictures `json:"pictures"`
+	NumberOfSeasons *float64  `json:"numberOfSeasons"`
+	Type            *string   `json:"type"`
+}
+
+// avatar, varsa animenin poster URL'sini döner
+func (r animeResponse) avatar() string {
+	if r.Pictures == nil {
+		return ""
+	}
+	return r.Pictures.Avatar
+}
+
+// validateSeasons, sezon bilgisi için gereken içerik tipini denetler
+func (r animeResponse) validateSeasons() error {
+	if r.Type == nil {
+		return internal.MissingField("anime", "type")
+	}
+	return nil
+}
+
+// seasonResponse, anime/<slug>/season/<n> yanıtıdır
+type seasonResponse struct {
+	Season *SeasonInfo `json:"season"`
+}
+
+// SeasonInfo, bir sezonun numarası ve bölümleridir
+type SeasonInfo struct {
+	SeasonNumber float64       `json:"season_number"`
+	Episodes     []EpisodeInfo `json:"episodes"`
+}
+
+// EpisodeInfo, sezon içindeki tek bir bölümdür
+type EpisodeInfo struct {
+	EpisodeNumber *float64 `json:"episodeNumber"`
+}
+
+// validate, sezon bilgisi varsa bölüm numaralarının varlığını denetler.
+// Henüz yayınlanmamış sezonlar için "season" alanı boş gelebilir.
+func (r seasonResponse) validate() error {
+	if r.Season == nil {
+		return nil
+	}
+	for i, ep := range r.Season.Episodes {
+		if ep.EpisodeNumber == nil {
+			return internal.MissingField("season", "season.episodes[%d].episodeNumber", i)
+		}
+	}
+	return nil
+}
+
+// episodeResponse, anime/<slug>/season/<n>/episode/<m> yanıtıdır
+type episodeResponse struct {
+	Fansubs     *[]FansubInfo `json:"fansubs"`
+	EpisodeData *EpisodeData  `json:"episodeData"`
+}
+
+// FansubInfo, bir bölümü çeviren fansub grubudur
+type FansubInfo struct {
+	ID         *string `json:"id"`
+	Name       *string `json:"name"`
+	SecureName *string `json:"secureName"`
+	Is4K       bool    `json:"is4K"`
+}
+
+// EpisodeData, bölümün video dosyalarıdır
+type EpisodeData struct {
+	Files *[]VideoFile `json:"files"`
+}
+
+// VideoFile, tek bir çözünürlüğe ait video dosyasıdır
+type VideoFile struct {
+	File       *string  `json:"file"`
+	Resolution *float64 `json:"resolution"`
+}
+
+// validateFansubs, fansub listesinin ve zorunlu alanlarının varlığını denetler
+func (r episodeResponse) validateFansubs() error {
+	if r.Fansubs == nil {
+		return internal.MissingField("episode", "fansubs")
+	}
+	for i, f := range *r.Fansubs {
+		if f.Is4K {
+			continue
+		}
+		switch {
+		case f.ID == nil:
+			return internal.MissingField("episode", "fansubs[%d].id", i)
+		case f.Name == nil:
+			return internal.MissingField("episode", "fansubs[%d].name", i)
+		case f.SecureName == nil:
+			return internal.MissingField("episode", "fansubs[%d].secureName", i)
+		}
+	}
+	return nil
+}
+
+// validateFiles, video dosyası listesinin varlığını denetler
+func (r episodeResponse) validateFiles() error {
+	if r.EpisodeData == nil {
+		return internal.MissingField("episode", "episodeData")
+	}
+	if r.EpisodeData.Files == nil {
+		return internal.MissingField("episode", "episodeData.files")
+	}
+	return nil
+}
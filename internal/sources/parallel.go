@@ -0,0 +1,91 @@
+package sources
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWorkers, aynı anda yapılabilecek sezon isteklerinin varsayılan sayısıdır.
+const DefaultWorkers = 4
+
+var (
+	workersMu sync.RWMutex
+	workers   = DefaultWorkers
+)
+
+// SetWorkers, kaynakların paralel isteklerde kullanacağı işçi sayısını ayarlar.
+// 1'den küçük değerler varsayılana döner.
+func SetWorkers(n int) {
+	if n < 1 {
+		n = DefaultWorkers
+	}
+	workersMu.Lock()
+	workers = n
+	workersMu.Unlock()
+}
+
+// Workers, paralel isteklerde kullanılan işçi sayısını döner.
+func Workers() int {
+	workersMu.RLock()
+	defer workersMu.RUnlock()
+	return workers
+}
+
+// FetchAll, fetch'i 0..n-1 indeksleri için en fazla Workers() eşzamanlı çağrıyla çalıştırır.
+// Sonuçlar indeks sırasıyla döner, böylece istek tamamlanma sırası çıktıyı etkilemez.
+// İlk hatada kalan istekler iptal edilir ve o hata döner.
+func FetchAll[T any](ctx context.Context, n int, fetch func(ctx context.Context, i int) (T, error)) ([]T, error) {
+	results := make([]T, n)
+	if n == 0 {
+		return results, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	jobs := make(chan int)
+	limit := min(Workers(), n)
+
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res, err := fetch(ctx, i)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[i] = res
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
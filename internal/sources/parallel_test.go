@@ -0,0 +1,93 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// setTestWorkers, testin süresince işçi sayısını n yapar
+func setTestWorkers(t *testing.T, n int) {
+	t.Helper()
+	old := Workers()
+	SetWorkers(n)
+	t.Cleanup(func() { SetWorkers(old) })
+}
+
+func TestFetchAllOrderAndLimit(t *testing.T) {
+	const limit = 3
+	setTestWorkers(t, limit)
+
+	var active, peak atomic.Int32
+	results, err := FetchAll(context.Background(), 20, func(ctx context.Context, i int) (int, error) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// Sondaki indeksler önce biter; sıra yine de korunmalı
+		time.Sleep(time.Duration(20-i) * time.Millisecond / 4)
+		return i * i, nil
+	})
+	if err != nil {
+		t.Fatalf("FetchAll: %v", err)
+	}
+
+	for i, got := range results {
+		if got != i*i {
+			t.Fatalf("results[%d] = %d, want %d", i, got, i*i)
+		}
+	}
+	if p := peak.Load(); p > limit {
+		t.Errorf("eşzamanlı çağrı sayısı %d, en fazla %d olmalı", p, limit)
+	}
+}
+
+func TestFetchAllFirstErrorCancels(t *testing.T) {
+	setTestWorkers(t, 4)
+
+	errBoom := errors.New("boom")
+	var started, canceled atomic.Int32
+	_, err := FetchAll(context.Background(), 50, func(ctx context.Context, i int) (int, error) {
+		started.Add(1)
+		if i == 0 {
+			// Diğer işçiler istek ortasındayken hata dönülür
+			for started.Load() < 4 {
+				time.Sleep(time.Millisecond)
+			}
+			return 0, errBoom
+		}
+		select {
+		case <-ctx.Done():
+			canceled.Add(1)
+			return 0, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return i, nil
+		}
+	})
+
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("hata = %v, want %v", err, errBoom)
+	}
+	if c := canceled.Load(); c < 3 {
+		t.Errorf("iptal edilen işçi sayısı = %d, en az 3 olmalı", c)
+	}
+	if s := started.Load(); s >= 50 {
+		t.Errorf("hatadan sonra yeni istek başlatılmamalı; başlatılan %d", s)
+	}
+}
+
+func TestFetchAllEmpty(t *testing.T) {
+	results, err := FetchAll(context.Background(), 0, func(ctx context.Context, i int) (int, error) {
+		t.Fatal("n == 0 iken fetch çağrılmamalı")
+		return 0, nil
+	})
+	if err != nil || len(results) != 0 {
+		t.Fatalf("FetchAll(0) = %v, %v", results, err)
+	}
+}
@@ -0,0 +1,246 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/axrona/anitr-cli/internal/models"
+)
+
+// Bu dosya bir animenin bölüm listesini ve bölümlerin izlenebilir akışlarını
+// kaynaktan bağımsız biçimde çözen yardımcıları içerir. Etkileşimli menüler ve
+// etkileşimsiz alt komutlar (episodes, streams) aynı akışı kullanır.
+
+// ExtraInt, bölümün Extra alanındaki sayısal değeri int olarak döner.
+// JSON'dan gelen değerler float64, kaynakların ürettikleri int olabilir.
+func ExtraInt(extra map[string]interface{}, key string) (int, bool) {
+	switch v := extra[key].(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// SeasonIndexOf, bölümün sıfırdan başlayan sezon indeksini döner.
+// Sezon bilgisi yoksa ilk sezon kabul edilir.
+func SeasonIndexOf(ep models.Episode) int {
+	if sn, ok := ExtraInt(ep.Extra, "season_num"); ok && sn > 0 {
+		return sn - 1
+	}
+	return 0
+}
+
+// EpisodeIndexInSeason, verilen bölümün kendi sezonu içindeki sıfırdan başlayan indeksini döner.
+func EpisodeIndexInSeason(episodes []models.Episode, index, seasonIndex int) int {
+	seasonEpisodeIndex := 0
+	for i := 0; i < index && i < len(episodes); i++ {
+		if sn, ok := ExtraInt(episodes[i].Extra, "season_num"); ok && sn-1 == seasonIndex {
+			seasonEpisodeIndex++
+		}
+	}
+	return seasonEpisodeIndex
+}
+
+// LoadEpisodes, animenin bölüm listesini ve film olup olmadığını döner.
+// Kaynak film ayrımı yapabiliyorsa ve isMovie false ise içerik türü kaynaktan sorulur.
+// Filmler, anime adını taşıyan tek bir bölüm olarak döner.
+func LoadEpisodes(ctx context.Context, source models.AnimeSource, id int, slug, name string, isMovie bool) ([]models.Episode, bool, error) {
+	if provider, ok := source.(models.MovieProvider); ok && !isMovie {
+		var err error
+		isMovie, err = provider.IsMovie(ctx, models.SeasonParams{Id: &id, Slug: &slug})
+		if err != nil {
+			return nil, false, fmt.Errorf("içerik türü alınamadı: %w", err)
+		}
+	}
+
+	if isMovie {
+		return []models.Episode{{
+			Title: name,
+			Extra: map[string]interface{}{"season_num": float64(1)},
+		}}, true, nil
+	}
+
+	episodes, err := source.GetEpisodesData(ctx, models.EpisodeParams{SeasonID: &id, Slug: &slug})
+	if err != nil {
+		return nil, false, fmt.Errorf("bölüm verisi alınamadı: %w", err)
+	}
+	if len(episodes) == 0 {
+		return nil, false, fmt.Errorf("hiçbir bölüm bulunamadı")
+	}
+	return episodes, false, nil
+}
+
+// Streams, bir bölümün kaliteye göre (yüksekten düşüğe) sıralanmış akışlarıdır
+type Streams struct {
+	Labels     []string // Çözünürlük etiketleri ("1080p", "720p" gibi)
+	URLs       []string // Etiketlerle aynı sıradaki video URL'leri
+	CaptionURL string   // Varsa Türkçe altyazı URL'si
+}
+
+// WatchRequest, ResolveStreams için bölüm ve anime bilgileridir
+type WatchRequest struct {
+	Episodes    []models.Episode // Bölüm listesi
+	Index       int              // Seçilen bölümün listedeki yeri
+	ID          int              // Anime ID'si (ID ile çalışan kaynaklar için)
+	Slug        *string          // Slug ile çalışan kaynaklar için tanımlayıcı
+	SeasonIndex int              // Sezonun sıfırdan başlayan indeksi
+	FansubIndex int              // Fansub destekleyen kaynaklar için seçilen fansub'un sırası
+	IsMovie     bool             // Film mi dizi mi
+}
+
+// episodeNumbers, bölümün sezon numarasını ve bölüm numarasını döner
+func episodeNumbers(ep models.Episode) (seasonNum, episodeNum int, err error) {
+	seasonNum, ok := ExtraInt(ep.Extra, "season_num")
+	if !ok {
+		return 0, 0, fmt.Errorf("season_num beklenen formatta değil")
+	}
+	episodeNum, ok = ExtraInt(ep.Extra, "episode_num")
+	if !ok {
+		episodeNum = ep.Number
+	}
+	return seasonNum, episodeNum, nil
+}
+
+// checkRequest, isteğin kaynak ve bölüm bilgilerini doğrular ve seçilen bölümü döner
+func checkRequest(source models.AnimeSource, req WatchRequest) (Info, models.Episode, error) {
+	info, ok := Lookup(source)
+	if !ok {
+		return Info{}, models.Episode{}, fmt.Errorf("geçersiz kaynak: %v", source)
+	}
+	if info.Capabilities.Slug && req.Slug == nil {
+		return Info{}, models.Episode{}, fmt.Errorf("slug gerekli")
+	}
+	if req.Index < 0 || req.Index >= len(req.Episodes) {
+		return Info{}, models.Episode{}, fmt.Errorf("index out of range")
+	}
+	return info, req.Episodes[req.Index], nil
+}
+
+// Fansubs, fansub destekleyen kaynaklar için seçilen bölümün fansub listesini döner.
+// Kaynak fansub desteklemiyorsa boş liste döner.
+func Fansubs(ctx context.Context, source models.AnimeSource, req WatchRequest) ([]models.Fansub, error) {
+	_, ep, err := checkRequest(source, req)
+	if err != nil {
+		return nil, err
+	}
+	provider, ok := source.(models.FansubProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	seasonNum, episodeNum, err := episodeNumbers(ep)
+	if err != nil {
+		return nil, err
+	}
+	fansubData, err := provider.GetFansubsData(ctx, models.FansubParams{
+		Slug:       req.Slug,
+		Id:         &req.ID,
+		SeasonNum:  &seasonNum,
+		EpisodeNum: &episodeNum,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fansub data API çağrısı başarısız: %w", err)
+	}
+	return fansubData, nil
+}
+
+// FindFansub, fansub listesinde sıra numarası (1'den başlar), ID, ad ya da güvenli ada göre arama yapar
+func FindFansub(fansubs []models.Fansub, query string) (int, bool) {
+	if n, err := strconv.Atoi(query); err == nil && n >= 1 && n <= len(fansubs) {
+		return n - 1, true
+	}
+	for i, f := range fansubs {
+		for _, v := range []*string{f.ID, f.Name, f.SecureName} {
+			if v != nil && strings.EqualFold(*v, query) {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ResolveStreams, seçilen bölümün izlenebilir akışlarını ve altyazı bilgisini getirir.
+// Kaynak fansub destekliyorsa bölümün fansub listesi de döner.
+func ResolveStreams(ctx context.Context, source models.AnimeSource, req WatchRequest) (*Streams, []models.Fansub, error) {
+	info, ep, err := checkRequest(source, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sezon ve bölüm numaralarını al
+	seasonNum, episodeNum, err := episodeNumbers(ep)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sezon içerisindeki bölüm indeksini bul
+	seasonEpisodeIndex := EpisodeIndexInSeason(req.Episodes, req.Index, req.SeasonIndex)
+
+	// Kaynak destekliyorsa fansub listesini al
+	fansubData, err := Fansubs(ctx, source, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := source.(models.FansubProvider); ok {
+		if req.FansubIndex < 0 || req.FansubIndex >= len(fansubData) {
+			return nil, nil, fmt.Errorf("seçilen fansub indeksi geçersiz")
+		}
+	}
+
+	// İzlenebilir veri isteği yap
+	watches, err := source.GetWatchData(ctx, models.WatchParams{
+		Slug:    req.Slug,
+		Url:     &ep.ID,
+		Id:      &req.ID,
+		IsMovie: &req.IsMovie,
+		Extra: &map[string]interface{}{
+			"season_num":         seasonNum,
+			"episode_num":        episodeNum,
+			"seasonIndex":        req.SeasonIndex,
+			"episodeIndex":       seasonEpisodeIndex,
+			"fansubs":            fansubData,
+			"selected_fansub_id": req.FansubIndex,
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s izleme verisi alınamadı: %w", info.Name, err)
+	}
+	if len(watches) < 1 {
+		return nil, nil, fmt.Errorf("%s izleme verisi boş", info.Name)
+	}
+	w := watches[0]
+	if len(w.Urls) < len(w.Labels) {
+		return nil, nil, fmt.Errorf("%s izleme verisi eksik", info.Name)
+	}
+
+	// Kaliteye göre (etiket sayısal değerine göre) sırala
+	order := make([]int, len(w.Labels))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return labelValue(w.Labels[order[i]]) > labelValue(w.Labels[order[j]])
+	})
+
+	streams := &Streams{Labels: []string{}, URLs: []string{}}
+	for _, i := range order {
+		streams.Labels = append(streams.Labels, w.Labels[i])
+		streams.URLs = append(streams.URLs, w.Urls[i])
+	}
+	if w.TRCaption != nil {
+		streams.CaptionURL = *w.TRCaption
+	}
+
+	return streams, fansubData, nil
+}
+
+// labelValue, "1080p" gibi bir çözünürlük etiketinin sayısal değerini döner
+func labelValue(label string) int {
+	n, _ := strconv.Atoi(strings.TrimRight(label, "p"))
+	return n
+}
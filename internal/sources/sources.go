@@ -0,0 +1,154 @@
+// Package sources, anime kaynaklarının kayıt defterini (registry) içerir.
+// Her kaynak paketi init() içinde kendini buraya kaydeder; menüler, config'teki
+// default_source ve geçmiş kayıtları kaynakları bu kayıt defteri üzerinden çözer.
+package sources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/axrona/anitr-cli/internal/models"
+)
+
+// Capabilities, bir kaynağın desteklediği özellikleri tanımlar.
+// Slug dışındaki alanlar Register sırasında kaynağın uyguladığı
+// isteğe bağlı arayüzlerden (models.FansubProvider vb.) doldurulur.
+type Capabilities struct {
+	Slug      bool // Animeler sayısal ID yerine slug ile tanımlanır
+	Fansubs   bool // Bölüm başına fansub seçimi (models.FansubProvider)
+	Subtitles bool // Seçilebilir altyazılar (models.SubtitleProvider)
+	Movies    bool // Film içerikleri (models.MovieProvider)
+	Seasons   bool // Çok sezonlu içerikler (models.SeasonProvider)
+}
+
+// detectCapabilities, kaynağın uyguladığı isteğe bağlı arayüzlere göre yetenekleri belirler.
+func detectCapabilities(src models.AnimeSource, declared Capabilities) Capabilities {
+	caps := Capabilities{Slug: declared.Slug}
+	_, caps.Fansubs = src.(models.FansubProvider)
+	_, caps.Subtitles = src.(models.SubtitleProvider)
+	_, caps.Movies = src.(models.MovieProvider)
+	_, caps.Seasons = src.(models.SeasonProvider)
+	return caps
+}
+
+// Info, kayıt defterindeki bir kaynağın bilgilerini tutar.
+type Info struct {
+	ID           string             // Kararlı kimlik ("openanime", "animecix"); config ve geçmiş anahtarı
+	Name         string             // Menülerde gösterilen ad
+	Order        int                // Menülerdeki sıralama (küçük olan önce gelir)
+	Capabilities Capabilities       // Kaynağın desteklediği özellikler
+	Source       models.AnimeSource // Kaynak implementasyonu
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Info)
+)
+
+// normalize, kimlik karşılaştırmaları için verilen değeri küçük harfe çevirir ve boşlukları kırpar.
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Register, bir kaynağı kayıt defterine ekler.
+// Boş ID, nil kaynak ya da aynı ID ile ikinci kayıt programlama hatasıdır ve panic'e yol açar.
+func Register(info Info) {
+	id := normalize(info.ID)
+	if id == "" {
+		panic("sources: kaynak ID'si boş olamaz")
+	}
+	if info.Source == nil {
+		panic(fmt.Sprintf("sources: %s için kaynak nil", id))
+	}
+	if info.Name == "" {
+		info.Name = info.Source.Source()
+	}
+	info.ID = id
+	info.Capabilities = detectCapabilities(info.Source, info.Capabilities)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := registry[id]; exists {
+		panic(fmt.Sprintf("sources: %s zaten kayıtlı", id))
+	}
+	registry[id] = info
+}
+
+// Get, ID'ye ya da görünen ada göre (büyük/küçük harf duyarsız) kaynağı döner.
+func Get(idOrName string) (Info, bool) {
+	key := normalize(idOrName)
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if info, ok := registry[key]; ok {
+		return info, true
+	}
+	for _, info := range registry {
+		if normalize(info.Name) == key {
+			return info, true
+		}
+	}
+	return Info{}, false
+}
+
+// Lookup, verilen kaynak implementasyonunun kayıt bilgisini döner.
+func Lookup(src models.AnimeSource) (Info, bool) {
+	if src == nil {
+		return Info{}, false
+	}
+	return Get(src.Source())
+}
+
+// ID, verilen kaynağın kararlı kimliğini döner.
+// Kaynak kayıtlı değilse adının küçük harfli hâli kullanılır.
+func ID(src models.AnimeSource) string {
+	if info, ok := Lookup(src); ok {
+		return info.ID
+	}
+	if src == nil {
+		return ""
+	}
+	return normalize(src.Source())
+}
+
+// List, kayıtlı kaynakları menü sırasına göre döner.
+func List() []Info {
+	mu.RLock()
+	list := make([]Info, 0, len(registry))
+	for _, info := range registry {
+		list = append(list, info)
+	}
+	mu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Order != list[j].Order {
+			return list[i].Order < list[j].Order
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// Names, kayıtlı kaynakların görünen adlarını menü sırasına göre döner.
+func Names() []string {
+	list := List()
+	names := make([]string, 0, len(list))
+	for _, info := range list {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+// Default, menü sırasındaki ilk kaynağı döner.
+// Hiç kaynak kayıtlı değilse ok false olur.
+func Default() (Info, bool) {
+	list := List()
+	if len(list) == 0 {
+		return Info{}, false
+	}
+	return list[0], true
+}
@@ -0,0 +1,199 @@
+// Package sourcetest, kaynak paketlerinin ağ erişimi olmadan test edilebilmesi için
+// httptest.Server tabanlı bir kayıt/oynatma (record/replay) düzeneği sunar.
+//
+// Oynatma modunda her rota testdata altındaki bir JSON dosyasından yanıtlanır.
+// ANITR_RECORD=1 ile çalıştırıldığında istekler gerçek sunuculara iletilir ve
+// gelen yanıtlar aynı dosyalara yazılır:
+//
+//	ANITR_RECORD=1 go test ./internal/sources/...
+//
+// Fixture içindeki {{server}} ifadesi test sunucusunun adresiyle değiştirilir;
+// böylece yanıtlardaki mutlak URL'ler de yerel sunucuya yönlenir. Kayıt sonrası
+// yanıtlarda kalan gerçek mutlak URL'lerin elle {{server}} ile değiştirilmesi gerekir.
+// Yönlendirmeler kaydedilmez; Redirect ile testte tanımlanır.
+package sourcetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// RecordEnv, kayıt modunu açan ortam değişkenidir.
+const RecordEnv = "ANITR_RECORD"
+
+// Server, kaydedilmiş yanıtları sunan test sunucusudur.
+type Server struct {
+	*httptest.Server
+
+	t         testing.TB
+	dir       string
+	record    bool
+	mu        sync.RWMutex
+	routes    map[string]string // "path?query" → fixture dosyası
+	redirects map[string]string // "path?query" → yönlendirme hedefi
+	upstreams map[string]string // yol öneki → gerçek sunucu adresi
+}
+
+// NewServer, dir altındaki fixture'ları sunan bir test sunucusu başlatır.
+// Sunucu test bitiminde kapatılır.
+func NewServer(t testing.TB, dir string) *Server {
+	t.Helper()
+
+	s := &Server{
+		t:         t,
+		dir:       dir,
+		record:    os.Getenv(RecordEnv) == "1",
+		routes:    make(map[string]string),
+		redirects: make(map[string]string),
+		upstreams: make(map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// URLFor, sunucu adresine verilen yolu ekler.
+func (s *Server) URLFor(path string) string {
+	return s.URL + path
+}
+
+// Upstream, prefix ile başlayan isteklerin kayıt modunda hangi gerçek sunucuya
+// iletileceğini belirtir. İletimde önek yoldan çıkarılır.
+func (s *Server) Upstream(prefix, base string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.upstreams[prefix] = strings.TrimRight(base, "/")
+}
+
+// Handle, route ("path" ya da "path?query") isteğini fixture dosyasıyla yanıtlar.
+func (s *Server) Handle(route, fixture string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[route] = fixture
+}
+
+// Redirect, route isteğini target adresine yönlendirir.
+// Hedef yol sunucuda boş bir 200 yanıtıyla karşılanır.
+func (s *Server) Redirect(route, target string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.redirects[route] = target
+}
+
+// routeKey, isteğin rota anahtarını döner.
+func routeKey(r *http.Request) string {
+	if r.URL.RawQuery == "" {
+		return r.URL.Path
+	}
+	return r.URL.Path + "?" + r.URL.RawQuery
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	key := routeKey(r)
+
+	s.mu.RLock()
+	fixture, ok := s.routes[key]
+	target, redirect := s.redirects[key]
+	isTarget := false
+	for _, t := range s.redirects {
+		if t == key || s.URL+key == t {
+			isTarget = true
+			break
+		}
+	}
+	s.mu.RUnlock()
+
+	switch {
+	case redirect:
+		http.Redirect(w, r, target, http.StatusFound)
+		return
+	case isTarget:
+		w.WriteHeader(http.StatusOK)
+		return
+	case !ok:
+		s.t.Errorf("sourcetest: beklenmeyen istek: %s %s", r.Method, key)
+		http.NotFound(w, r)
+		return
+	}
+
+	path := filepath.Join(s.dir, fixture)
+	if s.record {
+		if err := s.recordFixture(r, path); err != nil {
+			s.t.Errorf("sourcetest: %s kaydedilemedi: %v", fixture, err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		s.t.Errorf("sourcetest: fixture okunamadı: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body = bytes.ReplaceAll(body, []byte("{{server}}"), []byte(s.URL))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// recordFixture, isteği gerçek sunucuya iletir ve yanıtı path'e yazar.
+func (s *Server) recordFixture(r *http.Request, path string) error {
+	s.mu.RLock()
+	var upstream string
+	rest := r.URL.Path
+	for prefix, base := range s.upstreams {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			upstream = base
+			rest = strings.TrimPrefix(r.URL.Path, prefix)
+			break
+		}
+	}
+	s.mu.RUnlock()
+
+	if upstream == "" {
+		return fmt.Errorf("%s için gerçek sunucu tanımlı değil", r.URL.Path)
+	}
+
+	url := upstream + rest
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header = r.Header.Clone()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Okunabilir fixture'lar için JSON'u girintili yaz
+	var pretty bytes.Buffer
+	if json.Indent(&pretty, body, "", "  ") == nil {
+		body = append(pretty.Bytes(), '\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0644)
+}
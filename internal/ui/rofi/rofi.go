@@ -2,10 +2,12 @@ package rofi
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/axrona/anitr-cli/internal"
 )
@@ -41,9 +43,16 @@ func SelectionList(params internal.UiParams) (string, error) {
 	}
 
 	// Seçenekler listesini "rofi" komutunun standart girişi için uygun formata çevir
+	// Durum işaretleri satırın sonuna eklenir, seçimden sonra öğenin kendisine geri çevrilir
 	input := bytes.NewBufferString("")
+	display := make(map[string]string)
 	for _, opt := range *params.List {
-		input.WriteString(opt + "\n")
+		line := opt
+		if badge := params.Badges[opt]; badge != "" {
+			line = opt + "  " + badge
+			display[line] = opt
+		}
+		input.WriteString(line + "\n")
 	}
 
 	// "rofi" komutunu çalıştırmak için komut satırını oluştur
@@ -59,6 +68,9 @@ func SelectionList(params internal.UiParams) (string, error) {
 
 	// Seçilen öğeyi trimleyip döndür
 	selection := strings.TrimSpace(string(out))
+	if opt, ok := display[selection]; ok {
+		selection = opt
+	}
 	return selection, nil
 }
 
@@ -110,3 +122,35 @@ func ShowErrorBox(message string) error {
 
 	return nil
 }
+
+// Countdown, rofi ile "Şimdi başlat" / "İptal" seçeneklerini gösterir.
+// Kullanıcı seconds saniye içinde seçim yapmazsa rofi kapatılır ve true döner;
+// İptal seçilirse ya da rofi Esc ile kapatılırsa false döner.
+func Countdown(params internal.UiParams, seconds int) bool {
+	if err := isRofiExist(); err != nil {
+		return true
+	}
+
+	message := fmt.Sprintf("%s (%d sn)", params.Label, seconds)
+	args := []string{"-dmenu", "-p", "anitr-cli", "-mesg", message}
+	if params.RofiFlags != nil {
+		flags := strings.Split(*params.RofiFlags, " ")
+		args = append(args, flags...)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(seconds)*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "rofi", args...)
+	cmd.Stdin = bytes.NewBufferString("Şimdi başlat\nİptal\n")
+
+	out, err := cmd.Output()
+	if ctx.Err() != nil {
+		// Süre doldu
+		return true
+	}
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(out)) != "İptal"
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// countdownTickMsg, geri sayımın bir saniye ilerlediğini bildirir
+type countdownTickMsg struct{}
+
+// CountdownModel, iptal edilebilir geri sayım modelidir
+type CountdownModel struct {
+	label     string
+	remaining int
+	cancelled bool
+	quitting  bool
+}
+
+// countdownTick, bir saniye sonra geri sayımı ilerletir
+func countdownTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return countdownTickMsg{} })
+}
+
+// ShowCountdown, label'ı kalan süreyle birlikte gösterir ve seconds saniye geri sayar.
+// Süre dolarsa ya da Enter'a basılırsa true, Esc/Ctrl+C/q ile iptal edilirse false döner.
+func ShowCountdown(label string, seconds int) bool {
+	p := tea.NewProgram(CountdownModel{label: label, remaining: seconds})
+	m, err := p.Run()
+	if err != nil {
+		// Terminal kullanılamıyorsa beklemeden devam et
+		return true
+	}
+	return !m.(CountdownModel).cancelled
+}
+
+func (m CountdownModel) Init() tea.Cmd {
+	return countdownTick()
+}
+
+func (m CountdownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			m.quitting = true
+			return m, tea.Quit
+		case "enter":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	case countdownTickMsg:
+		m.remaining--
+		if m.remaining <= 0 {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, countdownTick()
+	}
+	return m, nil
+}
+
+func (m CountdownModel) View() string {
+	if m.cancelled {
+		return fmt.Sprintf("✘ %s (iptal edildi)\n", m.label)
+	}
+	if m.quitting {
+		return fmt.Sprintf("✔ %s\n", m.label)
+	}
+	return fmt.Sprintf("%s %s %s\n%s\n",
+		pinkHighlight.Render("⏵"), m.label, pinkHighlight.Render(fmt.Sprintf("%d", m.remaining)),
+		headerStyle.Render("Enter: şimdi başlat • Esc: iptal"))
+}
@@ -8,7 +8,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/axrona/anitr-cli/internal"
 	"github.com/charmbracelet/bubbles/list"
@@ -54,32 +53,40 @@ var (
 	headerStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#444")).
 			Italic(true)
+
+	badgeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(filterInputFg))
 )
 
 // Spinner modeli
 type SpinnerModel struct {
-	spinner  spinner.Model
-	label    string
-	quitting bool
+	spinner   spinner.Model
+	label     string
+	cancel    func()
+	cancelled bool
+	quitting  bool
 }
 
-func ShowSpinner(label string, done chan struct{}) {
+// spinnerDoneMsg, beklenen işin bittiğini spinner'a bildirir
+type spinnerDoneMsg struct{}
+
+// ShowSpinner, done kapanana kadar spinner gösterir.
+// Kullanıcı Esc ya da Ctrl+C'ye basarsa cancel çağrılır ve spinner kapanır.
+func ShowSpinner(label string, done <-chan struct{}, cancel func()) {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#e45cc0"))
 
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-done:
-			fmt.Printf("\r✔ %s\n", label)
-			return
-		case <-ticker.C:
-			s, _ = s.Update(spinner.TickMsg{})
-			fmt.Printf("\r%s %s", s.View(), label)
-		}
+	p := tea.NewProgram(SpinnerModel{spinner: s, label: label, cancel: cancel})
+
+	go func() {
+		<-done
+		p.Send(spinnerDoneMsg{})
+	}()
+
+	if _, err := p.Run(); err != nil {
+		// Terminal kullanılamıyorsa sadece işin bitmesini bekle
+		<-done
 	}
 }
 
@@ -91,10 +98,17 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c", "q":
+		case "ctrl+c", "esc":
+			if m.cancel != nil {
+				m.cancel()
+			}
+			m.cancelled = true
 			m.quitting = true
 			return m, tea.Quit
 		}
+	case spinnerDoneMsg:
+		m.quitting = true
+		return m, tea.Quit
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -106,8 +120,11 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m SpinnerModel) View() string {
+	if m.cancelled {
+		return fmt.Sprintf("✘ %s (iptal edildi)\n", m.label)
+	}
 	if m.quitting {
-		return ""
+		return fmt.Sprintf("✔ %s\n", m.label)
 	}
 	return fmt.Sprintf("%s %s", m.spinner.View(), m.label)
 }
@@ -246,6 +263,7 @@ func (i checkboxItem) FilterValue() string { return i.TitleStr }
 // Render delegate
 type slimDelegate struct {
 	list.DefaultDelegate
+	badges map[string]string // Öğe -> başlığın yanında gösterilecek durum
 }
 
 func (d slimDelegate) Height() int  { return 1 }
@@ -299,9 +317,18 @@ func (d slimDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 		prefix = selectionMark
 	}
 
+	// Durum işareti (izlendi, ilerleme çubuğu vb.) başlığın sonuna eklenir
+	badge := ""
+	if b, ok := d.badges[item.FilterValue()]; ok && b != "" {
+		badge = " " + badgeStyle.Render(b)
+	}
+
 	// Başlığı truncate et
-	availableWidth := m.Width() - lipgloss.Width(prefix) - 4
-	displayTitle := truncate.StringWithTail(title, uint(availableWidth), "...")
+	availableWidth := m.Width() - lipgloss.Width(prefix) - lipgloss.Width(badge) - 4
+	if availableWidth < 0 {
+		availableWidth = 0
+	}
+	displayTitle := truncate.StringWithTail(title, uint(availableWidth), "...") + badge
 
 	// Satır stili
 	line := prefix + displayTitle
@@ -352,7 +379,7 @@ func NewSelectionListModel(params internal.UiParams) SelectionListModel {
 	}
 
 	const defaultWidth, defaultHeight = 48, 20
-	l := list.New(items, slimDelegate{}, defaultWidth, defaultHeight)
+	l := list.New(items, slimDelegate{badges: params.Badges}, defaultWidth, defaultHeight)
 
 	titleStyle := lipgloss.NewStyle().Align(lipgloss.Center).Bold(true)
 	l.Title = titleStyle.Render(params.Label)
@@ -528,7 +555,7 @@ func NewMultiSelectionListModel(params internal.UiParams) MultiSelectionListMode
 	}
 
 	const defaultWidth, defaultHeight = 48, 20
-	l := list.New(items, slimDelegate{}, defaultWidth, defaultHeight)
+	l := list.New(items, slimDelegate{badges: params.Badges}, defaultWidth, defaultHeight)
 	titleStyle := lipgloss.NewStyle().Align(lipgloss.Center).Bold(true)
 	l.Title = titleStyle.Render(params.Label)
 	l.SetShowStatusBar(false)
@@ -1,11 +1,13 @@
 package ui
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
+	"sync"
 
 	"github.com/axrona/anitr-cli/internal"
 	"github.com/axrona/anitr-cli/internal/ui/rofi"
@@ -92,6 +94,15 @@ func MultiSelectList(params internal.UiParams) ([]string, error) {
 	return response, nil
 }
 
+// Countdown, iptal edilebilir bir geri sayım gösterir.
+// Süre dolarsa ya da kullanıcı hemen başlatmayı seçerse true, iptal ederse false döner.
+func Countdown(params internal.UiParams, seconds int) bool {
+	if params.Mode == "rofi" {
+		return rofi.Countdown(params, seconds)
+	}
+	return tui.ShowCountdown(params.Label, seconds)
+}
+
 // Hata gösterir
 func ShowError(params internal.UiParams, message string) {
 	if params.Mode == "rofi" {
@@ -104,9 +115,29 @@ func ShowError(params internal.UiParams, message string) {
 	}
 }
 
-// Spinner (rofiye yok tabi)
-func ShowLoading(params internal.UiParams, message string, done chan struct{}) {
-	if params.Mode == "tui" {
-		tui.ShowSpinner(message, done)
+// Loading, parent'tan türetilmiş iptal edilebilir bir context ve durdurma fonksiyonu döner.
+// TUI modunda iş sürerken spinner gösterilir; Esc ya da Ctrl+C context'i iptal eder.
+// stop çağrıldığında spinner kapanır ve context serbest bırakılır (rofiye spinner yok tabi).
+func Loading(parent context.Context, params internal.UiParams, message string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	if params.Mode != "tui" {
+		return ctx, cancel
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		tui.ShowSpinner(message, done, cancel)
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(done)
+			<-finished
+			cancel()
+		})
 	}
+	return ctx, stop
 }
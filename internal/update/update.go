@@ -1,14 +1,13 @@
 package update
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/axrona/anitr-cli/internal/httpx"
 )
 
 const (
@@ -21,30 +20,22 @@ type githubRelease struct {
 	TagName string `json:"tag_name"`
 }
 
+// updateTimeout, açılıştaki güncelleme kontrolünün azami süresi
+const updateTimeout = 5 * time.Second
+
 // GitHub API'den JSON verisi çeker
-func fetchAPI(url string) (*githubRelease, error) {
+func fetchAPI(ctx context.Context, url string) (*githubRelease, error) {
 	if url == "" {
 		return nil, errors.New("API URL boş")
 	}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("API'ye erişim başarısız: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API hatası: HTTP %d", resp.StatusCode)
-	}
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("API'den veri okunamadı: %w", err)
-	}
-
 	var release githubRelease
-	if err = json.Unmarshal(respBody, &release); err != nil {
-		return nil, fmt.Errorf("API verisi ayrıştırılamadı: %w", err)
+	if err := httpx.GetJSON(ctx, url, nil, &release); err != nil {
+		var statusErr *httpx.StatusError
+		if errors.As(err, &statusErr) {
+			return nil, fmt.Errorf("API hatası: HTTP %d", statusErr.StatusCode)
+		}
+		return nil, fmt.Errorf("API'ye erişim başarısız: %w", err)
 	}
 
 	if release.TagName == "" {
@@ -55,8 +46,8 @@ func fetchAPI(url string) (*githubRelease, error) {
 }
 
 // Sürüm kontrolü yapar, yeni bir güncelleme olup olmadığını döner
-func FetchUpdates() (string, error) {
-	release, err := fetchAPI(githubAPI)
+func FetchUpdates(ctx context.Context) (string, error) {
+	release, err := fetchAPI(ctx, githubAPI)
 	if err != nil {
 		return "", fmt.Errorf("güncelleme verileri alınamadı: %w", err)
 	}
@@ -87,7 +78,10 @@ func Version() string {
 
 // Güncellemeleri kontrol eder ve varsa kullanıcıya bildirir
 func CheckUpdates() {
-	msg, err := FetchUpdates()
+	ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+	defer cancel()
+
+	msg, err := FetchUpdates(ctx)
 	if err != nil {
 		fmt.Println(ColorRed + "Güncelleme kontrolü sırasında bir hata oluştu: " + err.Error() + ColorReset)
 		time.Sleep(2 * time.Second)
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CompletionThreshold, bir bölümün izlendi sayılacağı noktadır.
+// Percent sıfırdan büyükse sürenin yüzdesi, değilse bitişe kalan saniye (FromEnd) kullanılır.
+type CompletionThreshold struct {
+	Percent float64 // Örn. 90: sürenin %90'ı izlenince
+	FromEnd float64 // Örn. 300: bitişe 5 dakika kala
+}
+
+// DefaultCompletionThreshold, config'te eşik belirtilmediğinde kullanılır
+var DefaultCompletionThreshold = CompletionThreshold{FromEnd: 300}
+
+// shortEpisodePercent, bitişe kalan saniye eşiği bölümün yarısından uzunsa
+// (kısa bölümler) yerine kullanılan yüzdedir
+const shortEpisodePercent = 90
+
+var (
+	completionMu sync.RWMutex
+	completion   = DefaultCompletionThreshold
+)
+
+// SetCompletionThreshold, geçmişte bölümün izlendi sayılacağı eşiği ayarlar
+func SetCompletionThreshold(t CompletionThreshold) {
+	completionMu.Lock()
+	completion = t
+	completionMu.Unlock()
+}
+
+// completionThreshold, güncel eşiği döner
+func completionThreshold() CompletionThreshold {
+	completionMu.RLock()
+	defer completionMu.RUnlock()
+	return completion
+}
+
+// ParseCompletionThreshold, "90%" / "%90" biçimindeki yüzdeyi ya da "300" / "300s"
+// biçimindeki bitişe kalan saniyeyi çözümler. Boş değer için varsayılan eşik döner.
+func ParseCompletionThreshold(s string) (CompletionThreshold, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultCompletionThreshold, nil
+	}
+
+	if strings.HasPrefix(s, "%") || strings.HasSuffix(s, "%") {
+		p, err := strconv.ParseFloat(strings.Trim(s, "% "), 64)
+		if err != nil || p <= 0 || p > 100 {
+			return CompletionThreshold{}, fmt.Errorf("geçersiz tamamlanma yüzdesi: %s (1-100 arası olmalı)", s)
+		}
+		return CompletionThreshold{Percent: p}, nil
+	}
+
+	sec, err := strconv.ParseFloat(strings.TrimSuffix(s, "s"), 64)
+	if err != nil || sec < 0 {
+		return CompletionThreshold{}, fmt.Errorf("geçersiz tamamlanma eşiği: %s (örnek: 90%% ya da 300)", s)
+	}
+	return CompletionThreshold{FromEnd: sec}, nil
+}
+
+// Reached, verilen konumda bölümün izlendi sayılıp sayılmayacağını döner.
+// Bitişe kalan saniye eşiği bölümün yarısından uzunsa (ör. 5 dakikadan kısa bölümlerde
+// 300 saniye) bölüm başlar başlamaz izlendi sayılmaması için yüzde 90 kullanılır.
+func (t CompletionThreshold) Reached(position, duration float64) bool {
+	if duration <= 0 {
+		return false
+	}
+	percent := t.Percent
+	if percent <= 0 && t.FromEnd >= duration/2 {
+		percent = shortEpisodePercent
+	}
+	if percent > 0 {
+		return position >= duration*percent/100
+	}
+	return position >= duration-t.FromEnd
+}
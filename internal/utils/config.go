@@ -2,19 +2,49 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"time"
+
+	"github.com/axrona/anitr-cli/internal/httpx"
 )
 
 // Config struct
 type Config struct {
-	DefaultSource string `json:"default_source"`
-	HistoryLimit  int    `json:"history_limit"`
-	DisableRPC    *bool  `json:"disable_rpc"`
-	DownloadDir string `json:"download_dir"`
+	DefaultSource string   `json:"default_source"`
+	HistoryLimit  int      `json:"history_limit"`
+	DisableRPC    *bool    `json:"disable_rpc"`
+	DownloadDir   string   `json:"download_dir"`
+	HTTPTimeout   int      `json:"http_timeout"`   // Saniye cinsinden istek zaman aşımı (0: varsayılan)
+	HTTPRetries   *int     `json:"http_retries"`   // 5xx/zaman aşımı hatalarında ek deneme sayısı
+	FetchWorkers  int      `json:"fetch_workers"`  // Sezonlar alınırken aynı anda yapılacak istek sayısı (0: varsayılan)
+	Player        string   `json:"player"`         // Oynatıcı: mpv (varsayılan), iina, vlc ya da command
+	PlayerCommand []string `json:"player_command"` // player "command" ise çalıştırılacak komut ve argümanları
+	Autoplay      bool     `json:"autoplay"`       // Bölüm bitince sonraki bölüm geri sayımla otomatik oynatılır
+	AutoplayLimit int      `json:"autoplay_limit"` // Art arda otomatik oynatılacak en fazla bölüm (0: son bölüme kadar)
+
+	HistoryAllSources bool `json:"history_all_sources"` // Geçmiş menüsünde tüm kaynakların kayıtları birlikte gösterilir
+
+	// Bölümün izlendi sayılacağı eşik: "90%" (sürenin yüzdesi) ya da "300" (bitişe kalan saniye)
+	CompletionThreshold string `json:"completion_threshold"`
 }
 
 // LoadConfig config'i yükler
 func LoadConfig(path string) (*Config, error) {
+	cfg, err := readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.DownloadDir == "" {
+		cfg.DownloadDir = DefaultDownloadDir()
+	}
+
+	return cfg, nil
+}
+
+// readConfig, config'i varsayılan değerler uygulanmadan okur
+func readConfig(path string) (*Config, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -25,10 +55,43 @@ func LoadConfig(path string) (*Config, error) {
 	if err := json.NewDecoder(file).Decode(&cfg); err != nil {
 		return nil, err
 	}
-	
-	if cfg.DownloadDir == "" {
-    	cfg.DownloadDir = DefaultDownloadDir()
-	}	
-
 	return &cfg, nil
 }
+
+// UpdateConfig, config'i kilit altında okur, fn ile değiştirir ve atomik olarak yazar.
+// Dosya yoksa boş config üzerinden oluşturulur. Aynı anda çalışan oturumlar
+// birbirinin yaptığı değişiklikleri ezmez; fn yalnızca değiştirilecek alanlara dokunmalıdır.
+func UpdateConfig(path string, fn func(*Config)) error {
+	return withFileLock(path, func() error {
+		cfg, err := readConfig(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return fmt.Errorf("config okunamadı: %w", err)
+			}
+			cfg = &Config{}
+		}
+		fn(cfg)
+
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return fmt.Errorf("config serialize edilemedi: %w", err)
+		}
+		if err := WriteFileAtomic(path, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("config yazılamadı: %w", err)
+		}
+		return nil
+	})
+}
+
+// HTTPOptions config'teki HTTP ayarlarını httpx.Options'a çevirir.
+// Belirtilmeyen alanlar için varsayılan değerler kullanılır.
+func (c *Config) HTTPOptions() httpx.Options {
+	opts := httpx.DefaultOptions
+	if c.HTTPTimeout > 0 {
+		opts.Timeout = time.Duration(c.HTTPTimeout) * time.Second
+	}
+	if c.HTTPRetries != nil {
+		opts.Retries = *c.HTTPRetries
+	}
+	return opts
+}
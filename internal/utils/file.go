@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic, veriyi aynı klasördeki geçici bir dosyaya yazıp fsync ettikten sonra
+// hedefin üzerine taşır. Yazma yarıda kalırsa (çökme, elektrik kesintisi) eski dosya bozulmaz.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("geçici dosya oluşturulamadı: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // rename başarılıysa dosya zaten yoktur
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("geçici dosya yazılamadı: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("geçici dosya diske yazılamadı: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("geçici dosya kapatılamadı: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return fmt.Errorf("dosya izinleri ayarlanamadı: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("dosya yerine taşınamadı: %w", err)
+	}
+
+	syncDir(dir)
+	return nil
+}
+
+// withFileLock, path için ayrı bir ".lock" dosyası üzerinde özel kilit alarak fn'i çalıştırır.
+// Kilit tavsiye niteliğindedir; aynı dosyayı okuyup yazan tüm anitr-cli süreçleri ve
+// goroutine'leri oku-değiştir-yaz döngüsünü bu fonksiyon içinde yapmalıdır.
+// Kilit, dosyanın kendisi yerine ayrı dosyada tutulur çünkü WriteFileAtomic dosyayı değiştirir.
+func withFileLock(path string, fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("klasör oluşturulamadı: %w", err)
+	}
+
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("kilit dosyası açılamadı: %w", err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("dosya kilitlenemedi: %w", err)
+	}
+	defer unlockFile(f)
+
+	return fn()
+}
@@ -2,9 +2,12 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/axrona/anitr-cli/internal/player"
@@ -12,35 +15,126 @@ import (
 
 // AnimeHistoryEntry, her anime için tutulacak bilgiler
 type AnimeHistoryEntry struct {
+	Title           string     `json:"title"` // Animenin görünen adı (yalnızca bilgi amaçlı)
 	LastEpisodeIdx  *int       `json:"lastEpisodeIdx"`
 	LastEpisodeName string     `json:"lastEpisodeName"`
-	AnimeId         *string    `json:"animeId"`
 	LastWatched     *time.Time `json:"lastWatched"`
+
+	// Bölüm indeksi -> bölümün izlenme kaydı
+	Episodes map[string]EpisodeRecord `json:"episodes,omitempty"`
+}
+
+// EpisodeRecord, tek bir bölümün izlenme kaydıdır
+type EpisodeRecord struct {
+	Watched   bool      `json:"watched,omitempty"` // Bölüm sonuna kadar izlendi mi
+	Position  float64   `json:"position"`          // Saniye cinsinden kalınan konum, izlenen bölümlerde 0
+	Duration  float64   `json:"duration"`          // Saniye cinsinden bölüm süresi
+	UpdatedAt time.Time `json:"updatedAt"`         // Kaydın güncellendiği zaman
+	Fansub    string    `json:"fansub,omitempty"`  // İzlenirken kullanılan fansub
+}
+
+// Progress, yarıda bırakılan bölümde izlenen oranı (0-1) döner.
+// İzlenen ya da konumu bilinmeyen bölümler için 0 döner.
+func (r EpisodeRecord) Progress() float64 {
+	if r.Watched || r.Duration <= 0 || r.Position <= 0 {
+		return 0
+	}
+	return min(r.Position/r.Duration, 1)
 }
 
-// AnimeHistory, source -> anime adı -> struct
+// Konum kaydı ayarları. Bölümün izlendi sayılacağı eşik için bkz. CompletionThreshold.
+const (
+	minResumePos     = 30               // Bundan kısa konumlar devam etmek için kaydedilmez
+	progressInterval = 30 * time.Second // Oynatma sırasında konumun kaydedilme aralığı
+)
+
+// AnimeHistory, source -> anime ID'si (slug kullanan kaynaklarda slug) -> struct.
+// Anime adı anahtar olarak kullanılmaz; kaynakta başlık değişse ya da iki anime
+// aynı adı taşısa da kayıtlar karışmaz.
 type AnimeHistory map[string]map[string]AnimeHistoryEntry
 
+// historyVersion, history.json'un güncel şema sürümüdür
+const historyVersion = 2
+
+// historyFile, history.json'un diskteki biçimidir
+type historyFile struct {
+	Version int          `json:"version"`
+	Sources AnimeHistory `json:"sources"`
+}
+
+// WatchedAt, kaydın son izlenme zamanını döner; bilinmiyorsa sıfır zaman
+func WatchedAt(entry AnimeHistoryEntry) time.Time {
+	if entry.LastWatched == nil {
+		return time.Time{}
+	}
+	return *entry.LastWatched
+}
+
 // getHistoryPath cross-platform olarak history.json yolunu döndürür
 func getHistoryPath() (string, error) {
-    // ConfigDir() ile aynı yeri kullanarak platformlar arasında tutarlılık sağlar.
-    historyDir := ConfigDir()
+	// ConfigDir() ile aynı yeri kullanarak platformlar arasında tutarlılık sağlar.
+	historyDir := ConfigDir()
 
-    // Klasör yoksa oluştur
-    if err := os.MkdirAll(historyDir, 0o755); err != nil {
-        return "", fmt.Errorf("history klasörü oluşturulamadı: %w", err)
-    }
+	// Klasör yoksa oluştur
+	if err := os.MkdirAll(historyDir, 0o755); err != nil {
+		return "", fmt.Errorf("history klasörü oluşturulamadı: %w", err)
+	}
 
-    return filepath.Join(historyDir, "history.json"), nil
+	return filepath.Join(historyDir, "history.json"), nil
 }
 
-// ReadAnimeHistory history.json'u okur, yoksa yeni oluşturur
+// ReadAnimeHistory history.json'u okur, yoksa yeni oluşturur.
+// Eski (sürümsüz, anime adıyla anahtarlanan) biçimdeki dosya ilk okumada
+// yedeklenip güncel biçime dönüştürülür.
 func ReadAnimeHistory() (AnimeHistory, error) {
 	path, err := getHistoryPath()
 	if err != nil {
 		return nil, err
 	}
 
+	var history AnimeHistory
+	err = withFileLock(path, func() error {
+		history, err = readHistoryFile(path)
+		return err
+	})
+	return history, err
+}
+
+// WriteAnimeHistory history.json'u yazar.
+// Okuyup değiştirerek yazmak için ModifyAnimeHistory kullanılmalıdır; aksi halde
+// aynı anda çalışan başka bir oturumun yazdıkları kaybolabilir.
+func WriteAnimeHistory(history AnimeHistory) error {
+	path, err := getHistoryPath()
+	if err != nil {
+		return err
+	}
+	return withFileLock(path, func() error {
+		return writeHistoryFile(path, history)
+	})
+}
+
+// ModifyAnimeHistory, history.json'u kilit altında okur, fn ile değiştirir ve yazar.
+// Böylece aynı anda çalışan goroutine'ler ve anitr-cli süreçleri birbirinin
+// değişikliklerini ezmez. fn hata dönerse dosya yazılmaz.
+func ModifyAnimeHistory(fn func(AnimeHistory) error) error {
+	path, err := getHistoryPath()
+	if err != nil {
+		return err
+	}
+	return withFileLock(path, func() error {
+		history, err := readHistoryFile(path)
+		if err != nil {
+			return err
+		}
+		if err := fn(history); err != nil {
+			return err
+		}
+		return writeHistoryFile(path, history)
+	})
+}
+
+// readHistoryFile, history.json'u okur. Kilit tutulurken çağrılır.
+func readHistoryFile(path string) (AnimeHistory, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -49,89 +143,449 @@ func ReadAnimeHistory() (AnimeHistory, error) {
 		return nil, fmt.Errorf("history okunamadı: %w", err)
 	}
 
-	var history AnimeHistory
-	if err := json.Unmarshal(data, &history); err != nil {
+	var file historyFile
+	if err := json.Unmarshal(data, &file); err != nil {
 		return nil, fmt.Errorf("history parse edilemedi: %w", err)
 	}
-	return history, nil
-}
 
-// WriteAnimeHistory history.json'u yazar
-func WriteAnimeHistory(history AnimeHistory) error {
-	path, err := getHistoryPath()
-	if err != nil {
-		return err
+	switch {
+	case file.Version == 0:
+		return migrateHistoryFile(path, data)
+	case file.Version > historyVersion:
+		return nil, fmt.Errorf("history sürümü desteklenmiyor: %d (anitr-cli'yi güncelleyin)", file.Version)
 	}
-	data, err := json.MarshalIndent(history, "", "  ")
+
+	if file.Sources == nil {
+		file.Sources = make(AnimeHistory)
+	}
+	return file.Sources, nil
+}
+
+// writeHistoryFile, history.json'u atomik olarak yazar. Kilit tutulurken çağrılır.
+func writeHistoryFile(path string, history AnimeHistory) error {
+	data, err := json.MarshalIndent(historyFile{Version: historyVersion, Sources: history}, "", "  ")
 	if err != nil {
 		return fmt.Errorf("history serialize edilemedi: %w", err)
 	}
-	if err := os.WriteFile(path, data, 0o644); err != nil {
+	if err := WriteFileAtomic(path, data, 0o644); err != nil {
 		return fmt.Errorf("history yazılamadı: %w", err)
 	}
 	return nil
 }
 
-// UpdateAnimeHistory, mevcut MPV oturumu sırasında animeyi history.json'a kaydeder
-func UpdateAnimeHistory(socketPath, source, animeName, episodeName, animeId string, episodeIndex int, logger *Logger) {
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
+// updateEpisodeRecord, animenin ve idx. bölümün kaydını yoksa oluşturup fn ile günceller.
+// Anime başlığı, son izlenme zamanı ve bölüm kaydının güncellenme zamanı burada ayarlanır;
+// fn yalnızca çağırana özgü alanları değiştirir.
+func updateEpisodeRecord(history AnimeHistory, source, animeId, title string, idx int, fn func(*AnimeHistoryEntry, *EpisodeRecord)) {
+	sourceEntry, ok := history[source]
+	if !ok {
+		sourceEntry = make(map[string]AnimeHistoryEntry)
+	}
 
-	updated := false
-	for range ticker.C {
-		if !player.IsMPVRunning(socketPath) {
-			break
-		}
+	now := time.Now()
+	entry := sourceEntry[animeId]
+	if title != "" {
+		entry.Title = title
+	}
+	entry.LastWatched = &now
+	if entry.Episodes == nil {
+		entry.Episodes = make(map[string]EpisodeRecord)
+	}
+	key := strconv.Itoa(idx)
+	record := entry.Episodes[key]
+	record.UpdatedAt = now
 
-		durationVal, err1 := player.MPVSendCommand(socketPath, []interface{}{"get_property", "duration"})
-		timePosVal, err2 := player.MPVSendCommand(socketPath, []interface{}{"get_property", "time-pos"})
-		if err1 != nil || err2 != nil {
-			continue
+	fn(&entry, &record)
+
+	entry.Episodes[key] = record
+	sourceEntry[animeId] = entry
+	history[source] = sourceEntry
+}
+
+// setLast, bölümü animenin son izlenen bölümü yapar
+func (e *AnimeHistoryEntry) setLast(ep PlaybackEpisode) {
+	idx := ep.Index
+	e.LastEpisodeIdx = &idx
+	e.LastEpisodeName = ep.Name
+}
+
+// recordAnimeHistory, bölümü izlendi olarak history.json'a yazar ve kalınan konumunu sıfırlar
+func recordAnimeHistory(source, animeId, title string, ep PlaybackEpisode) error {
+	return ModifyAnimeHistory(func(history AnimeHistory) error {
+		updateEpisodeRecord(history, source, animeId, title, ep.Index, func(entry *AnimeHistoryEntry, record *EpisodeRecord) {
+			entry.setLast(ep)
+			record.Watched = true
+			record.Position = 0
+			if ep.Fansub != "" {
+				record.Fansub = ep.Fansub
+			}
+		})
+		return nil
+	})
+}
+
+// startEpisode, oynatma başlar başlamaz bölümü "başlandı" olarak history.json'a yazar.
+// Bölüm son izlenen bölüm olur ancak izlendi sayılmaz; böylece oynatıcı erken
+// kapatılsa da Geçmiş ve --go bu bölümden devam eder. Daha önce izlenmiş bir
+// bölüm tekrar açılıyorsa izlendi bilgisi ve kayıtlı konum korunur.
+func startEpisode(source, animeId, title string, ep PlaybackEpisode) error {
+	return ModifyAnimeHistory(func(history AnimeHistory) error {
+		updateEpisodeRecord(history, source, animeId, title, ep.Index, func(entry *AnimeHistoryEntry, record *EpisodeRecord) {
+			entry.setLast(ep)
+			if ep.Fansub != "" {
+				record.Fansub = ep.Fansub
+			}
+		})
+		return nil
+	})
+}
+
+// saveEpisodeProgress, bölümde kalınan konumu history.json'a yazar ve animenin
+// son izlenme zamanını günceller.
+// Daha önce izlenmiş bir bölüm tekrar izleniyorsa izlendi bilgisi korunur.
+func saveEpisodeProgress(source, animeId, title string, ep PlaybackEpisode, state player.State) error {
+	if state.Position < minResumePos || state.Duration <= 0 {
+		return nil
+	}
+
+	return ModifyAnimeHistory(func(history AnimeHistory) error {
+		updateEpisodeRecord(history, source, animeId, title, ep.Index, func(entry *AnimeHistoryEntry, record *EpisodeRecord) {
+			record.Position = state.Position
+			record.Duration = state.Duration
+			if ep.Fansub != "" {
+				record.Fansub = ep.Fansub
+			}
+		})
+		return nil
+	})
+}
+
+// ResumePosition, bölüm için kaydedilmiş ve devam edilebilecek konumu döner.
+// Bitmek üzere olan ya da çok kısa izlenen bölümler için false döner.
+func ResumePosition(source, animeId string, episodeIndex int) (EpisodeRecord, bool) {
+	history, err := ReadAnimeHistory()
+	if err != nil {
+		return EpisodeRecord{}, false
+	}
+
+	progress, ok := history[source][animeId].Episodes[strconv.Itoa(episodeIndex)]
+	if !ok || progress.Position < minResumePos {
+		return EpisodeRecord{}, false
+	}
+	if completionThreshold().Reached(progress.Position, progress.Duration) {
+		return EpisodeRecord{}, false
+	}
+	return progress, true
+}
+
+// EpisodeRecords, animenin bölüm kayıtlarını bölüm indeksine göre döner
+func EpisodeRecords(source, animeId string) map[int]EpisodeRecord {
+	history, err := ReadAnimeHistory()
+	if err != nil {
+		return nil
+	}
+
+	records := make(map[int]EpisodeRecord)
+	for key, record := range history[source][animeId].Episodes {
+		if idx, err := strconv.Atoi(key); err == nil {
+			records[idx] = record
 		}
+	}
+	return records
+}
 
-		duration, ok1 := durationVal.(float64)
-		progress, ok2 := timePosVal.(float64)
-		if !ok1 || !ok2 {
-			continue
+// MarkEpisodes, bölümleri elle izlendi ya da izlenmedi olarak işaretler.
+// İzlendi işaretlenen bölümlerden son izlenenden ilerideki varsa "son izlenen" bölüm o olur.
+// İzlenmedi işaretlenen bölümlerin kaydı silinir; son izlenen bölüm bunlardan biriyse
+// kalan en ileri izlenmiş bölüme geri alınır.
+// episodeNames animenin tüm bölüm adları, indices işaretlenecek bölümlerin indeksleridir.
+func MarkEpisodes(source, animeId, title string, episodeNames []string, indices []int, watched bool) error {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	return ModifyAnimeHistory(func(history AnimeHistory) error {
+		sourceEntry, ok := history[source]
+		if !ok {
+			sourceEntry = make(map[string]AnimeHistoryEntry)
 		}
 
-		if updated {
-			continue
+		now := time.Now()
+		entry := sourceEntry[animeId]
+		if title != "" {
+			entry.Title = title
+		}
+		if entry.Episodes == nil {
+			entry.Episodes = make(map[string]EpisodeRecord)
 		}
 
-		if progress >= duration-300 { // son 5 dakika
-			history, err := ReadAnimeHistory()
-			if err != nil {
-				logger.LogError(err)
+		for _, idx := range indices {
+			if idx < 0 || idx >= len(episodeNames) {
+				continue
+			}
+			key := strconv.Itoa(idx)
+			if !watched {
+				delete(entry.Episodes, key)
 				continue
 			}
 
-			sourceEntry, ok := history[source]
-			if !ok {
-				sourceEntry = make(map[string]AnimeHistoryEntry)
+			record := entry.Episodes[key]
+			record.Watched = true
+			record.Position = 0
+			record.UpdatedAt = now
+			entry.Episodes[key] = record
+
+			if entry.LastEpisodeIdx == nil || idx > *entry.LastEpisodeIdx {
+				last := idx
+				entry.LastEpisodeIdx = &last
+				entry.LastEpisodeName = episodeNames[idx]
+				entry.LastWatched = &now
 			}
+		}
+
+		if !watched && entry.LastEpisodeIdx != nil {
+			if _, ok := entry.Episodes[strconv.Itoa(*entry.LastEpisodeIdx)]; !ok {
+				rewindLastEpisode(&entry, episodeNames, slices.Min(indices)-1)
+			}
+		}
+
+		if len(entry.Episodes) == 0 {
+			entry.Episodes = nil
+		}
+		if entry.LastEpisodeIdx == nil && entry.Episodes == nil {
+			// Animeye ait hiçbir izlenme bilgisi kalmadı
+			delete(sourceEntry, animeId)
+		} else {
+			sourceEntry[animeId] = entry
+		}
+		history[source] = sourceEntry
+
+		return nil
+	})
+}
+
+// rewindLastEpisode, "son izlenen" bölümü kalan en ileri izlenmiş bölüme geri alır.
+// Bölüm kaydı tutulmayan eski geçmişlerde fallback, yani işaretlenen ilk bölümden
+// bir önceki bölüm kullanılır; o da yoksa son izlenen bilgisi silinir.
+func rewindLastEpisode(entry *AnimeHistoryEntry, episodeNames []string, fallback int) {
+	last := -1
+	for key, record := range entry.Episodes {
+		idx, err := strconv.Atoi(key)
+		if err == nil && record.Watched && idx > last && idx < len(episodeNames) {
+			last = idx
+		}
+	}
+	if last < 0 && fallback < len(episodeNames) {
+		last = fallback
+	}
+	if last < 0 {
+		entry.LastEpisodeIdx = nil
+		entry.LastEpisodeName = ""
+		entry.LastWatched = nil
+		return
+	}
+
+	entry.LastEpisodeIdx = &last
+	entry.LastEpisodeName = episodeNames[last]
+}
+
+// RemoveAnimeHistory, animeyi geçmişten tamamen siler
+func RemoveAnimeHistory(source, animeId string) error {
+	return ModifyAnimeHistory(func(history AnimeHistory) error {
+		if _, ok := history[source][animeId]; !ok {
+			return fmt.Errorf("geçmişte bulunamadı: %s/%s", source, animeId)
+		}
+		delete(history[source], animeId)
+		if len(history[source]) == 0 {
+			delete(history, source)
+		}
+		return nil
+	})
+}
 
-			animeEntry, ok := sourceEntry[animeName]
-			if !ok {
-				animeEntry = AnimeHistoryEntry{}
+// ClearAnimeHistory, kaynağın geçmişini siler ve silinen anime sayısını döner.
+// source boşsa tüm kaynakların geçmişi silinir.
+func ClearAnimeHistory(source string) (int, error) {
+	removed := 0
+	err := ModifyAnimeHistory(func(history AnimeHistory) error {
+		for name, animes := range history {
+			if source == "" || name == source {
+				removed += len(animes)
+				delete(history, name)
 			}
+		}
+		return nil
+	})
+	return removed, err
+}
 
-			time := time.Now()
+// SetLastEpisode, animenin son izlenen bölümünü n. bölüm (1'den başlar) olarak ayarlar.
+// Bölüm izlendi işaretlenir ve ilerideki bölümlerin kayıtları silinir; Geçmiş ve --go
+// bir sonraki bölümden devam eder. n 0 ise izlenme bilgileri sıfırlanır ve anime
+// ilk bölümden başlar. name boşsa bölüm adı "n. Bölüm" olarak yazılır.
+func SetLastEpisode(source, animeId string, n int, name string) error {
+	if n < 0 {
+		return fmt.Errorf("geçersiz bölüm numarası: %d", n)
+	}
+
+	return ModifyAnimeHistory(func(history AnimeHistory) error {
+		entry, ok := history[source][animeId]
+		if !ok {
+			return fmt.Errorf("geçmişte bulunamadı: %s/%s", source, animeId)
+		}
 
-			animeEntry = AnimeHistoryEntry{
-				LastEpisodeIdx:  &episodeIndex,
-				LastEpisodeName: episodeName,
-				AnimeId:         &animeId,
-				LastWatched:     &time,
+		now := time.Now()
+		if n == 0 {
+			// İlk bölüm "başlandı" olarak kalır; böylece anime Geçmiş'te görünmeye devam eder
+			first := 0
+			entry.LastEpisodeIdx = &first
+			entry.LastEpisodeName = name
+			if entry.LastEpisodeName == "" {
+				entry.LastEpisodeName = "1. Bölüm"
 			}
-			sourceEntry[animeName] = animeEntry
-			history[source] = sourceEntry
+			entry.LastWatched = &now
+			entry.Episodes = map[string]EpisodeRecord{"0": {UpdatedAt: now}}
+		} else {
+			for key := range entry.Episodes {
+				if idx, err := strconv.Atoi(key); err == nil && idx >= n {
+					delete(entry.Episodes, key)
+				}
+			}
+			setWatchedUpTo(&entry, n, name, now)
+		}
 
-			if err := WriteAnimeHistory(history); err != nil {
-				logger.LogError(err)
-				continue
+		history[source][animeId] = entry
+		return nil
+	})
+}
+
+// setWatchedUpTo, n. bölümü (1'den başlar) son izlenen bölüm yapar ve izlendi işaretler.
+// name boşsa ve son bölüm değişmiyorsa mevcut ad korunur, değişiyorsa "n. Bölüm" yazılır.
+func setWatchedUpTo(entry *AnimeHistoryEntry, n int, name string, at time.Time) {
+	idx := n - 1
+	if name == "" && entry.LastEpisodeIdx != nil && *entry.LastEpisodeIdx == idx {
+		name = entry.LastEpisodeName
+	}
+	if name == "" {
+		name = fmt.Sprintf("%d. Bölüm", n)
+	}
+
+	entry.LastEpisodeIdx = &idx
+	entry.LastEpisodeName = name
+	entry.LastWatched = &at
+
+	if entry.Episodes == nil {
+		entry.Episodes = make(map[string]EpisodeRecord)
+	}
+	key := strconv.Itoa(idx)
+	record := entry.Episodes[key]
+	record.Watched = true
+	record.Position = 0
+	record.UpdatedAt = at
+	entry.Episodes[key] = record
+}
+
+// PlaybackEpisode, oynatıcıdaki bir videonun bölüm bilgisidir
+type PlaybackEpisode struct {
+	Index  int    // Bölümün anime bölüm listesindeki sırası
+	Name   string // Bölüm adı
+	Fansub string // Oynatılan fansub (bilinmiyorsa boş)
+}
+
+// UpdateAnimeHistory, oynatma oturumu sırasında animeyi history.json'a kaydeder.
+// episodes, oynatıcıdaki videoların sırasıyla bölüm bilgileridir; oynatma listesinde
+// güncel bölüm oynatıcının bildirdiği liste sırasına (playlist-pos) göre seçilir.
+// Bölüm, oynatma başlar başlamaz "başlandı" olarak kaydedilir. Oynatıcının olay akışı
+// dinlenir; kalınan konum düzenli aralıklarla, başka bölüme geçilirken ve oynatıcı
+// kapanırken kaydedilir. Tamamlanma eşiğine (SetCompletionThreshold) ulaşıldığında
+// ya da video bittiğinde bölüm izlendi sayılır. Oynatıcı ilerleme bildiremiyorsa
+// (player.ErrUnsupported) ne kadar izlendiği bilinemeyeceğinden bölüm yalnızca
+// "başlandı" olarak kalır; izlendi işareti elle verilir.
+func UpdateAnimeHistory(p player.Player, source, animeId, title string, episodes []PlaybackEpisode, logger *Logger) {
+	if len(episodes) == 0 {
+		return
+	}
+
+	if err := startEpisode(source, animeId, title, episodes[0]); err != nil {
+		logger.LogError(err)
+	}
+
+	if _, err := p.Position(); errors.Is(err, player.ErrUnsupported) {
+		return
+	}
+
+	// current, durumdaki liste sırasına karşılık gelen bölümü döner
+	current := func(st player.State) (PlaybackEpisode, bool) {
+		if st.PlaylistPos < 0 || st.PlaylistPos >= len(episodes) {
+			return PlaybackEpisode{}, false
+		}
+		return episodes[st.PlaylistPos], true
+	}
+
+	// saveLast, izlendi sayılmayan bölümün son konumunu kaydeder
+	recorded := make(map[int]bool)
+	saveLast := func(st player.State) {
+		ep, ok := current(st)
+		if !ok || recorded[st.PlaylistPos] {
+			return
+		}
+		if err := saveEpisodeProgress(source, animeId, title, ep, st); err != nil {
+			logger.LogError(err)
+		}
+	}
+
+	events, cancel := player.Subscribe(p)
+	defer cancel()
+
+	var (
+		threshold = completionThreshold()
+		last      player.State
+		lastSaved time.Time
+	)
+	for ev := range events {
+		st := ev.State
+
+		// Oynatma listesinde başka bölüme geçildi
+		if ev.Type == player.EventPlaylistPos {
+			saveLast(last)
+			last = st
+			lastSaved = time.Time{}
+			if ep, ok := current(st); ok && !recorded[st.PlaylistPos] {
+				if err := startEpisode(source, animeId, title, ep); err != nil {
+					logger.LogError(err)
+				}
 			}
-			updated = true
+			continue
+		}
+		last = st
+
+		ep, ok := current(st)
+		if !ok || recorded[st.PlaylistPos] {
+			continue
+		}
+
+		watched := ev.Type == player.EventEOF || threshold.Reached(st.Position, st.Duration)
+		if !watched {
+			// Kalınan konumu düzenli olarak kaydet
+			if time.Since(lastSaved) >= progressInterval {
+				if err := saveEpisodeProgress(source, animeId, title, ep, st); err != nil {
+					logger.LogError(err)
+				}
+				lastSaved = time.Now()
+			}
+			continue
+		}
+
+		if err := recordAnimeHistory(source, animeId, title, ep); err != nil {
+			logger.LogError(err)
+			continue
+		}
+		recorded[st.PlaylistPos] = true
+		if len(episodes) == 1 {
+			return
 		}
 	}
+
+	// Oynatıcı bölüm bitmeden kapandı, son konumu kaydet
+	saveLast(last)
 }
@@ -0,0 +1,528 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Geçmişin dışa/içe aktarılabildiği biçimler
+const (
+	FormatMALXML      = "mal-xml"
+	FormatAniListJSON = "anilist-json"
+	FormatCSV         = "csv"
+)
+
+// HistoryFormats, desteklenen dışa/içe aktarma biçimleridir
+var HistoryFormats = []string{FormatMALXML, FormatAniListJSON, FormatCSV}
+
+// historyTagPrefix, dışa aktarılan kayıtlara anitr-cli kaynağını ve ID'sini işlemek için
+// kullanılır (MAL'de my_tags, AniList'te notes). İçe aktarırken kayıt bu sayede
+// arama yapılmadan eşleştirilir.
+const historyTagPrefix = "anitr-cli:"
+
+// HistoryRecord, bir animenin geçmişinin biçimden bağımsız özetidir
+type HistoryRecord struct {
+	Source          string    // Kaynak kimliği (bilinmiyorsa boş)
+	AnimeID         string    // Kaynaktaki anime ID'si ya da slug'ı (bilinmiyorsa boş)
+	Title           string    // Anime adı
+	WatchedEpisodes int       // Baştan itibaren izlenen bölüm sayısı
+	LastEpisodeName string    // Son izlenen bölümün adı (bilinmiyorsa boş)
+	StartedAt       time.Time // İzlemeye başlanan tarih (bilinmiyorsa sıfır)
+	LastWatched     time.Time // Son izlenme zamanı (bilinmiyorsa sıfır)
+	MALID           int       // MyAnimeList ID'si (bilinmiyorsa 0)
+	AniListID       int       // AniList ID'si (bilinmiyorsa 0)
+}
+
+// ListID, kaydın verilen biçimin sitesindeki ID'sini döner; CSV'de ya da ID bilinmiyorsa 0
+func (r HistoryRecord) ListID(format string) int {
+	switch format {
+	case FormatMALXML:
+		return r.MALID
+	case FormatAniListJSON:
+		return r.AniListID
+	}
+	return 0
+}
+
+// tag, kaydın kaynak ve ID etiketini döner
+func (r HistoryRecord) tag() string {
+	if r.Source == "" || r.AnimeID == "" {
+		return ""
+	}
+	return historyTagPrefix + r.Source + ":" + r.AnimeID
+}
+
+// parseHistoryTag, metindeki "anitr-cli:kaynak:id" etiketini çözümler
+func parseHistoryTag(s string) (source, animeId string, ok bool) {
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' }) {
+		rest, found := strings.CutPrefix(field, historyTagPrefix)
+		if !found {
+			continue
+		}
+		if source, animeId, ok = strings.Cut(rest, ":"); ok && source != "" && animeId != "" {
+			return source, animeId, true
+		}
+	}
+	return "", "", false
+}
+
+// HistoryRecords, geçmişi son izlenme zamanına göre (yeniden eskiye) sıralı kayıtlara çevirir.
+// Hiç bölümü açılmamış kayıtlar atlanır.
+func HistoryRecords(history AnimeHistory) []HistoryRecord {
+	var records []HistoryRecord
+	for source, animes := range history {
+		for animeId, entry := range animes {
+			if entry.LastEpisodeIdx == nil {
+				continue
+			}
+			rec := HistoryRecord{
+				Source:          source,
+				AnimeID:         animeId,
+				Title:           entry.Title,
+				WatchedEpisodes: WatchedEpisodeCount(entry),
+				LastEpisodeName: entry.LastEpisodeName,
+				LastWatched:     WatchedAt(entry),
+			}
+			// Başlangıç tarihi, en eski bölüm kaydıdır
+			for _, ep := range entry.Episodes {
+				if !ep.UpdatedAt.IsZero() && (rec.StartedAt.IsZero() || ep.UpdatedAt.Before(rec.StartedAt)) {
+					rec.StartedAt = ep.UpdatedAt
+				}
+			}
+			records = append(records, rec)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].LastWatched.Equal(records[j].LastWatched) {
+			return records[i].LastWatched.After(records[j].LastWatched)
+		}
+		return records[i].Title < records[j].Title
+	})
+	return records
+}
+
+// WatchedEpisodeCount, son açılan bölüme göre baştan itibaren izlenen bölüm sayısını döner.
+// Son bölüm yarıda bırakıldıysa sayılmaz.
+func WatchedEpisodeCount(entry AnimeHistoryEntry) int {
+	if entry.LastEpisodeIdx == nil {
+		return 0
+	}
+	n := *entry.LastEpisodeIdx + 1
+	if record, ok := entry.Episodes[strconv.Itoa(*entry.LastEpisodeIdx)]; ok && !record.Watched {
+		n--
+	}
+	return n
+}
+
+// SeedHistory, içe aktarılan kaydı geçmişe işler ve işlenip işlenmediğini döner.
+// Son izlenen bölüm WatchedEpisodes'a göre ayarlanır ve izlendi olarak işaretlenir;
+// böylece Geçmiş ve --go bir sonraki bölümden devam eder. Geçmişteki kayıt daha
+// yakın zamanda izlendiyse ya da izlenen bölüm yoksa kayıt değiştirilmez.
+func SeedHistory(history AnimeHistory, rec HistoryRecord) bool {
+	if rec.Source == "" || rec.AnimeID == "" || rec.WatchedEpisodes <= 0 {
+		return false
+	}
+
+	sourceEntry, ok := history[rec.Source]
+	if !ok {
+		sourceEntry = make(map[string]AnimeHistoryEntry)
+	}
+	entry := sourceEntry[rec.AnimeID]
+
+	lastWatched := rec.LastWatched
+	if lastWatched.IsZero() {
+		lastWatched = time.Now()
+	}
+	if entry.LastEpisodeIdx != nil && !WatchedAt(entry).Before(lastWatched) {
+		return false
+	}
+
+	if entry.Title == "" {
+		entry.Title = rec.Title
+	}
+	setWatchedUpTo(&entry, rec.WatchedEpisodes, rec.LastEpisodeName, lastWatched)
+
+	sourceEntry[rec.AnimeID] = entry
+	history[rec.Source] = sourceEntry
+	return true
+}
+
+// ExportHistory, kayıtları verilen biçimde w'ye yazar. MAL ve AniList biçimlerinde
+// o sitedeki ID'si bilinmeyen kayıtlar yazılmaz (bkz. ResolveListIDs).
+func ExportHistory(w io.Writer, records []HistoryRecord, format string) error {
+	switch format {
+	case FormatMALXML:
+		return exportMALXML(w, records)
+	case FormatAniListJSON:
+		return exportAniListJSON(w, records)
+	case FormatCSV:
+		return exportCSV(w, records)
+	}
+	return fmt.Errorf("bilinmeyen biçim: %s (kullanılabilir: %s)", format, strings.Join(HistoryFormats, ", "))
+}
+
+// ParseHistoryExport, verilen biçimdeki dışa aktarma dosyasını kayıtlara çevirir.
+// Kaynağı ve ID'si bilinmeyen kayıtlarda Source ve AnimeID boş kalır.
+func ParseHistoryExport(r io.Reader, format string) ([]HistoryRecord, error) {
+	switch format {
+	case FormatMALXML:
+		return parseMALXML(r)
+	case FormatAniListJSON:
+		return parseAniListJSON(r)
+	case FormatCSV:
+		return parseCSV(r)
+	}
+	return nil, fmt.Errorf("bilinmeyen biçim: %s (kullanılabilir: %s)", format, strings.Join(HistoryFormats, ", "))
+}
+
+// ---- MyAnimeList XML ----
+
+// malDateLayout, MAL dışa aktarmasındaki tarih biçimidir; bilinmeyen tarih "0000-00-00" yazılır
+const (
+	malDateLayout = "2006-01-02"
+	malZeroDate   = "0000-00-00"
+)
+
+// malCDATA, MAL'in CDATA içinde yazdığı metin alanlarıdır
+type malCDATA struct {
+	Text string `xml:",cdata"`
+}
+
+// malAnime, MAL dışa aktarmasındaki tek bir anime kaydıdır
+type malAnime struct {
+	ID              int      `xml:"series_animedb_id"`
+	Title           malCDATA `xml:"series_title"`
+	Episodes        int      `xml:"series_episodes"`
+	WatchedEpisodes int      `xml:"my_watched_episodes"`
+	StartDate       string   `xml:"my_start_date"`
+	FinishDate      string   `xml:"my_finish_date"`
+	Status          string   `xml:"my_status"`
+	Tags            malCDATA `xml:"my_tags"`
+	UpdateOnImport  int      `xml:"update_on_import"`
+}
+
+// malExport, MAL'in "Export" sayfasının ürettiği XML'in kök öğesidir
+type malExport struct {
+	XMLName xml.Name `xml:"myanimelist"`
+	MyInfo  struct {
+		ExportType int `xml:"user_export_type"`
+	} `xml:"myinfo"`
+	Anime []malAnime `xml:"anime"`
+}
+
+func malDate(t time.Time) string {
+	if t.IsZero() {
+		return malZeroDate
+	}
+	return t.Format(malDateLayout)
+}
+
+func parseMALDate(s string) time.Time {
+	t, err := time.ParseInLocation(malDateLayout, strings.TrimSpace(s), time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// exportMALXML, kayıtları MAL'in dışa aktarma XML'i biçiminde yazar
+func exportMALXML(w io.Writer, records []HistoryRecord) error {
+	var out malExport
+	out.MyInfo.ExportType = 1 // anime listesi
+	for _, rec := range records {
+		if rec.MALID == 0 {
+			continue
+		}
+		out.Anime = append(out.Anime, malAnime{
+			ID:              rec.MALID,
+			Title:           malCDATA{rec.Title},
+			WatchedEpisodes: rec.WatchedEpisodes,
+			StartDate:       malDate(rec.StartedAt),
+			FinishDate:      malZeroDate,
+			Status:          "Watching",
+			Tags:            malCDATA{rec.tag()},
+			UpdateOnImport:  1,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("MAL XML yazılamadı: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func parseMALXML(r io.Reader) ([]HistoryRecord, error) {
+	var in malExport
+	if err := xml.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("MAL XML okunamadı: %w", err)
+	}
+
+	records := make([]HistoryRecord, 0, len(in.Anime))
+	for _, a := range in.Anime {
+		rec := HistoryRecord{
+			MALID:           a.ID,
+			Title:           strings.TrimSpace(a.Title.Text),
+			WatchedEpisodes: a.WatchedEpisodes,
+			StartedAt:       parseMALDate(a.StartDate),
+		}
+		// MAL son izlenme zamanı tutmaz; bitiş ya da başlangıç tarihi kullanılır
+		rec.LastWatched = parseMALDate(a.FinishDate)
+		if rec.LastWatched.IsZero() {
+			rec.LastWatched = rec.StartedAt
+		}
+		rec.Source, rec.AnimeID, _ = parseHistoryTag(a.Tags.Text)
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// ---- AniList JSON ----
+
+// anilistDate, AniList'in FuzzyDate tipidir
+type anilistDate struct {
+	Year  *int `json:"year"`
+	Month *int `json:"month"`
+	Day   *int `json:"day"`
+}
+
+// anilistEntry, AniList MediaList kaydıdır
+type anilistEntry struct {
+	MediaID     int         `json:"mediaId"`
+	Status      string      `json:"status"`
+	Progress    int         `json:"progress"`
+	StartedAt   anilistDate `json:"startedAt"`
+	CompletedAt anilistDate `json:"completedAt"`
+	UpdatedAt   int64       `json:"updatedAt"`
+	Notes       string      `json:"notes"`
+	Media       struct {
+		Title struct {
+			Romaji  string `json:"romaji,omitempty"`
+			English string `json:"english,omitempty"`
+			Native  string `json:"native,omitempty"`
+		} `json:"title"`
+	} `json:"media"`
+}
+
+// anilistCollection, AniList GraphQL API'sinin MediaListCollection yanıtıdır
+type anilistCollection struct {
+	Lists []struct {
+		Name    string         `json:"name"`
+		Status  string         `json:"status,omitempty"`
+		Entries []anilistEntry `json:"entries"`
+	} `json:"lists"`
+}
+
+// anilistExport, dosyanın kökü: API yanıtı olduğu gibi ({"data": ...}) ya da
+// doğrudan MediaListCollection olabilir
+type anilistExport struct {
+	Data *struct {
+		MediaListCollection anilistCollection `json:"MediaListCollection"`
+	} `json:"data,omitempty"`
+	anilistCollection
+}
+
+func toAniListDate(t time.Time) anilistDate {
+	if t.IsZero() {
+		return anilistDate{}
+	}
+	y, m, d := t.Date()
+	month := int(m)
+	return anilistDate{Year: &y, Month: &month, Day: &d}
+}
+
+func (d anilistDate) time() time.Time {
+	if d.Year == nil {
+		return time.Time{}
+	}
+	month, day := 1, 1
+	if d.Month != nil {
+		month = *d.Month
+	}
+	if d.Day != nil {
+		day = *d.Day
+	}
+	return time.Date(*d.Year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+}
+
+// exportAniListJSON, kayıtları AniList MediaListCollection biçiminde yazar
+func exportAniListJSON(w io.Writer, records []HistoryRecord) error {
+	var out anilistExport
+	out.Data = &struct {
+		MediaListCollection anilistCollection `json:"MediaListCollection"`
+	}{}
+	collection := &out.Data.MediaListCollection
+	collection.Lists = append(collection.Lists, struct {
+		Name    string         `json:"name"`
+		Status  string         `json:"status,omitempty"`
+		Entries []anilistEntry `json:"entries"`
+	}{Name: "Watching", Status: "CURRENT", Entries: []anilistEntry{}})
+
+	for _, rec := range records {
+		if rec.AniListID == 0 {
+			continue
+		}
+		entry := anilistEntry{
+			MediaID:   rec.AniListID,
+			Status:    "CURRENT",
+			Progress:  rec.WatchedEpisodes,
+			StartedAt: toAniListDate(rec.StartedAt),
+			Notes:     rec.tag(),
+		}
+		if !rec.LastWatched.IsZero() {
+			entry.UpdatedAt = rec.LastWatched.Unix()
+		}
+		entry.Media.Title.Romaji = rec.Title
+		collection.Lists[0].Entries = append(collection.Lists[0].Entries, entry)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("AniList JSON yazılamadı: %w", err)
+	}
+	return nil
+}
+
+func parseAniListJSON(r io.Reader) ([]HistoryRecord, error) {
+	var in anilistExport
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("AniList JSON okunamadı: %w", err)
+	}
+	collection := in.anilistCollection
+	if in.Data != nil {
+		collection = in.Data.MediaListCollection
+	}
+
+	var records []HistoryRecord
+	for _, list := range collection.Lists {
+		for _, e := range list.Entries {
+			title := e.Media.Title.Romaji
+			if title == "" {
+				title = e.Media.Title.English
+			}
+			if title == "" {
+				title = e.Media.Title.Native
+			}
+
+			rec := HistoryRecord{
+				AniListID:       e.MediaID,
+				Title:           title,
+				WatchedEpisodes: e.Progress,
+				StartedAt:       e.StartedAt.time(),
+			}
+			if e.UpdatedAt > 0 {
+				rec.LastWatched = time.Unix(e.UpdatedAt, 0)
+			} else if completed := e.CompletedAt.time(); !completed.IsZero() {
+				rec.LastWatched = completed
+			} else {
+				rec.LastWatched = rec.StartedAt
+			}
+			rec.Source, rec.AnimeID, _ = parseHistoryTag(e.Notes)
+			records = append(records, rec)
+		}
+	}
+	return records, nil
+}
+
+// ---- CSV ----
+
+// csvHeader, CSV dışa aktarmasının sütunlarıdır
+var csvHeader = []string{"source", "anime_id", "title", "watched_episodes", "last_episode", "started_at", "last_watched"}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func parseCSVTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func exportCSV(w io.Writer, records []HistoryRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if err := cw.Write([]string{
+			rec.Source,
+			rec.AnimeID,
+			rec.Title,
+			strconv.Itoa(rec.WatchedEpisodes),
+			rec.LastEpisodeName,
+			csvTime(rec.StartedAt),
+			csvTime(rec.LastWatched),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("CSV yazılamadı: %w", err)
+	}
+	return nil
+}
+
+func parseCSV(r io.Reader) ([]HistoryRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV okunamadı: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	// Sütunlar başlığa göre bulunur; sırası değişmiş ya da eksik sütunlu dosyalar da okunur
+	col := make(map[string]int)
+	for i, name := range rows[0] {
+		col[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := col["title"]; !ok {
+		return nil, fmt.Errorf("CSV başlığında title sütunu yok (beklenen: %s)", strings.Join(csvHeader, ","))
+	}
+	get := func(row []string, name string) string {
+		if i, ok := col[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	records := make([]HistoryRecord, 0, len(rows)-1)
+	for n, row := range rows[1:] {
+		watched, err := strconv.Atoi(get(row, "watched_episodes"))
+		if err != nil {
+			return nil, fmt.Errorf("CSV %d. satır: geçersiz watched_episodes: %q", n+2, get(row, "watched_episodes"))
+		}
+		records = append(records, HistoryRecord{
+			Source:          get(row, "source"),
+			AnimeID:         get(row, "anime_id"),
+			Title:           get(row, "title"),
+			WatchedEpisodes: watched,
+			LastEpisodeName: get(row, "last_episode"),
+			StartedAt:       parseCSVTime(get(row, "started_at")),
+			LastWatched:     parseCSVTime(get(row, "last_watched")),
+		})
+	}
+	return records, nil
+}
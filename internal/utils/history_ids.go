@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/axrona/anitr-cli/internal/httpx"
+)
+
+// anilistAPI, AniList GraphQL API adresidir
+var anilistAPI = "https://graphql.anilist.co"
+
+// anilistRateLimitWait, AniList hız sınırına (HTTP 429) takılınca tekrar denemeden önce beklenen süredir
+var anilistRateLimitWait = time.Minute
+
+// anilistMediaQuery, adıyla aranan animenin AniList ve MAL ID'lerini getirir
+const anilistMediaQuery = `query ($search: String) { Media(search: $search, type: ANIME) { id idMal } }`
+
+// anilistMediaResponse, anilistMediaQuery yanıtıdır
+type anilistMediaResponse struct {
+	Data struct {
+		Media *struct {
+			ID    int  `json:"id"`
+			IDMal *int `json:"idMal"`
+		} `json:"Media"`
+	} `json:"data"`
+}
+
+// ResolveListIDs, kayıtların MAL ve AniList ID'lerini AniList'te adıyla arayarak doldurur.
+// AniList'te bulunamayan kayıtların ID'leri 0 kalır; MAL karşılığı olmayanlarda yalnızca
+// AniList ID'si dolar. Hız sınırına takılınca bir kez beklenip tekrar denenir.
+func ResolveListIDs(ctx context.Context, records []HistoryRecord) error {
+	for i := range records {
+		if records[i].MALID != 0 && records[i].AniListID != 0 {
+			continue
+		}
+		title := strings.TrimSpace(records[i].Title)
+		if title == "" {
+			continue
+		}
+
+		malID, anilistID, err := searchAniList(ctx, title)
+		if err != nil {
+			return fmt.Errorf("%s için AniList ID'si alınamadı: %w", title, err)
+		}
+		if records[i].MALID == 0 {
+			records[i].MALID = malID
+		}
+		if records[i].AniListID == 0 {
+			records[i].AniListID = anilistID
+		}
+	}
+	return nil
+}
+
+// searchAniList, animeyi AniList'te adıyla arar; bulunamazsa ID'ler 0 döner
+func searchAniList(ctx context.Context, title string) (malID, anilistID int, err error) {
+	request := map[string]interface{}{
+		"query":     anilistMediaQuery,
+		"variables": map[string]string{"search": title},
+	}
+
+	var resp anilistMediaResponse
+	for attempt := 0; ; attempt++ {
+		err = httpx.PostJSON(ctx, anilistAPI, nil, request, &resp)
+
+		var statusErr *httpx.StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests && attempt == 0 {
+			select {
+			case <-ctx.Done():
+				return 0, 0, ctx.Err()
+			case <-time.After(anilistRateLimitWait):
+			}
+			continue
+		}
+		break
+	}
+
+	// AniList eşleşme bulamayınca 404 döner
+	var statusErr *httpx.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	media := resp.Data.Media
+	if media == nil {
+		return 0, 0, nil
+	}
+	if media.IDMal != nil {
+		malID = *media.IDMal
+	}
+	return malID, media.ID, nil
+}
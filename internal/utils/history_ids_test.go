@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolveListIDs(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// İlk istek hız sınırına takılır
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		var req struct {
+			Variables struct {
+				Search string `json:"search"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("istek çözümlenemedi: %v", err)
+		}
+		switch req.Variables.Search {
+		case "Sousou no Frieren":
+			w.Write([]byte(`{"data":{"Media":{"id":154587,"idMal":52991}}}`))
+		case "Yalnızca AniList":
+			w.Write([]byte(`{"data":{"Media":{"id":7,"idMal":null}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"data":{"Media":null},"errors":[{"message":"Not Found.","status":404}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	oldAPI, oldWait := anilistAPI, anilistRateLimitWait
+	anilistAPI, anilistRateLimitWait = srv.URL, time.Millisecond
+	t.Cleanup(func() { anilistAPI, anilistRateLimitWait = oldAPI, oldWait })
+
+	records := []HistoryRecord{
+		{Source: "animecix", AnimeID: "42", Title: "Sousou no Frieren", WatchedEpisodes: 3},
+		{Source: "animecix", AnimeID: "7", Title: "Yalnızca AniList", WatchedEpisodes: 1},
+		{Source: "openanime", AnimeID: "yok", Title: "Bulunmayan", WatchedEpisodes: 2},
+	}
+	if err := ResolveListIDs(context.Background(), records); err != nil {
+		t.Fatalf("ResolveListIDs: %v", err)
+	}
+
+	want := [][2]int{{52991, 154587}, {0, 7}, {0, 0}}
+	for i, rec := range records {
+		if rec.MALID != want[i][0] || rec.AniListID != want[i][1] {
+			t.Errorf("%s: MAL %d, AniList %d; %v olmalı", rec.Title, rec.MALID, rec.AniListID, want[i])
+		}
+	}
+
+	// ID'si bulunamayan kayıtlar o sitenin dosyasına yazılmaz
+	var buf bytes.Buffer
+	if err := ExportHistory(&buf, records, FormatMALXML); err != nil {
+		t.Fatalf("ExportHistory: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "<series_animedb_id>52991</series_animedb_id>") ||
+		strings.Contains(out, "Yalnızca AniList") || strings.Contains(out, "Bulunmayan") {
+		t.Errorf("MAL XML çıktısı yanlış:\n%s", out)
+	}
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// legacyHistoryEntry, sürümsüz (v1) history.json'daki anime kaydıdır.
+// v1'de anahtar anime adıydı, ID ise kaydın içinde tutuluyordu.
+type legacyHistoryEntry struct {
+	LastEpisodeIdx  *int                     `json:"lastEpisodeIdx"`
+	LastEpisodeName string                   `json:"lastEpisodeName"`
+	AnimeId         *string                  `json:"animeId"`
+	LastWatched     *time.Time               `json:"lastWatched"`
+	Episodes        map[string]EpisodeRecord `json:"episodes,omitempty"`
+}
+
+// migrateHistoryFile, v1 history.json'u yedekler, güncel biçime dönüştürüp yerine yazar.
+// Yedek path + ".v1.bak" olarak bir kez alınır; varsa üzerine yazılmaz. Kilit tutulurken çağrılır.
+func migrateHistoryFile(path string, data []byte) (AnimeHistory, error) {
+	history, err := migrateHistoryV1(data)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := path + ".v1.bak"
+	if _, err := os.Stat(backup); os.IsNotExist(err) {
+		if err := WriteFileAtomic(backup, data, 0o644); err != nil {
+			return nil, fmt.Errorf("history yedeği alınamadı: %w", err)
+		}
+	}
+
+	if err := writeHistoryFile(path, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// migrateHistoryV1, source -> anime adı -> kayıt biçimindeki eski geçmişi
+// source -> anime ID'si -> kayıt biçimine çevirir. Anime adı kaydın başlığı olur.
+// ID'si olmayan kayıtlar tekrar açılamayacağı için taşınmaz. Aynı ID'ye sahip
+// birden fazla kayıt (ör. kaynakta adı değişmiş anime) birleştirilir.
+func migrateHistoryV1(data []byte) (AnimeHistory, error) {
+	var legacy map[string]map[string]legacyHistoryEntry
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, fmt.Errorf("eski history parse edilemedi: %w", err)
+	}
+
+	history := make(AnimeHistory)
+	for source, animes := range legacy {
+		sourceEntry := make(map[string]AnimeHistoryEntry)
+		for animeName, old := range animes {
+			if old.AnimeId == nil || *old.AnimeId == "" {
+				continue
+			}
+			entry := AnimeHistoryEntry{
+				Title:           animeName,
+				LastEpisodeIdx:  old.LastEpisodeIdx,
+				LastEpisodeName: old.LastEpisodeName,
+				LastWatched:     old.LastWatched,
+				Episodes:        old.Episodes,
+			}
+			if existing, ok := sourceEntry[*old.AnimeId]; ok {
+				entry = mergeHistoryEntries(existing, entry)
+			}
+			sourceEntry[*old.AnimeId] = entry
+		}
+		if len(sourceEntry) > 0 {
+			history[source] = sourceEntry
+		}
+	}
+	return history, nil
+}
+
+// mergeHistoryEntries, aynı animeye ait iki kaydı birleştirir.
+// Başlık ve son izlenen bölüm daha yakın zamanda izlenen kayıttan alınır;
+// bölüm kayıtlarında her bölüm için daha yeni olan tutulur.
+func mergeHistoryEntries(a, b AnimeHistoryEntry) AnimeHistoryEntry {
+	newer, older := a, b
+	if WatchedAt(b).After(WatchedAt(a)) {
+		newer, older = b, a
+	}
+
+	merged := newer
+	if merged.LastEpisodeIdx == nil {
+		merged.LastEpisodeIdx = older.LastEpisodeIdx
+		merged.LastEpisodeName = older.LastEpisodeName
+		merged.LastWatched = older.LastWatched
+	}
+
+	if len(older.Episodes) > 0 {
+		episodes := make(map[string]EpisodeRecord, len(newer.Episodes)+len(older.Episodes))
+		for key, record := range older.Episodes {
+			episodes[key] = record
+		}
+		for key, record := range newer.Episodes {
+			if prev, ok := episodes[key]; !ok || !prev.UpdatedAt.After(record.UpdatedAt) {
+				episodes[key] = record
+			}
+		}
+		merged.Episodes = episodes
+	}
+	return merged
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadAnimeHistoryMigratesV1(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("APPDATA", dir)
+
+	// Aynı animenin eski ve yeni adı, ID'siz bir kayıt ve başka bir kaynak
+	legacy := `{
+  "animecix": {
+    "Eski Ad": {"lastEpisodeIdx": 2, "lastEpisodeName": "3. Bölüm", "animeId": "42", "lastWatched": "2025-01-01T10:00:00Z",
+      "episodes": {"1": {"position": 100, "duration": 1400, "updatedAt": "2025-01-01T09:00:00Z"}}},
+    "Yeni Ad": {"lastEpisodeIdx": 4, "lastEpisodeName": "5. Bölüm", "animeId": "42", "lastWatched": "2025-02-01T10:00:00Z"},
+    "Kimliksiz": {"lastEpisodeIdx": 0, "lastEpisodeName": "1. Bölüm", "animeId": null}
+  },
+  "openanime": {
+    "Aynı Ad": {"lastEpisodeIdx": 0, "lastEpisodeName": "1. Bölüm", "animeId": "ayni-ad", "lastWatched": "2025-03-01T10:00:00Z"}
+  }
+}`
+	path := filepath.Join(ConfigDir(), "history.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := ReadAnimeHistory()
+	if err != nil {
+		t.Fatalf("ReadAnimeHistory: %v", err)
+	}
+
+	if n := len(history["animecix"]); n != 1 {
+		t.Fatalf("animecix kayıt sayısı = %d, 1 olmalı", n)
+	}
+	entry := history["animecix"]["42"]
+	if entry.Title != "Yeni Ad" || entry.LastEpisodeIdx == nil || *entry.LastEpisodeIdx != 4 {
+		t.Errorf("birleştirilen kayıt yanlış: %+v", entry)
+	}
+	if _, ok := entry.Episodes["1"]; !ok {
+		t.Errorf("eski kaydın bölüm konumu taşınmadı: %+v", entry.Episodes)
+	}
+	if history["openanime"]["ayni-ad"].Title != "Aynı Ad" {
+		t.Errorf("openanime kaydı slug ile anahtarlanmadı: %+v", history["openanime"])
+	}
+
+	// Eski dosya yedeklenmeli
+	backup, err := os.ReadFile(path + ".v1.bak")
+	if err != nil || string(backup) != legacy {
+		t.Errorf("yedek alınmadı ya da farklı: %v", err)
+	}
+
+	// Dosya güncel sürümle yeniden yazılmış olmalı
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file historyFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != historyVersion {
+		t.Errorf("history.json dönüştürülmedi: sürüm %d, hata %v", file.Version, err)
+	}
+
+	// İkinci okuma dönüştürülmüş dosyayı aynen okumalı
+	again, err := ReadAnimeHistory()
+	if err != nil || again["animecix"]["42"].Title != "Yeni Ad" {
+		t.Errorf("dönüştürülmüş history okunamadı: %v", err)
+	}
+}
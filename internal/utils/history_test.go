@@ -0,0 +1,455 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestModifyAnimeHistoryConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("APPDATA", dir)
+
+	// Her goroutine farklı bir anime ekler; kilit olmadan yazmalar birbirini ezer
+	const writers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := ModifyAnimeHistory(func(h AnimeHistory) error {
+				if h["animecix"] == nil {
+					h["animecix"] = make(map[string]AnimeHistoryEntry)
+				}
+				h["animecix"][fmt.Sprint(i)] = AnimeHistoryEntry{Title: fmt.Sprintf("Anime %d", i)}
+				return nil
+			})
+			if err != nil {
+				t.Errorf("ModifyAnimeHistory: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	history, err := ReadAnimeHistory()
+	if err != nil {
+		t.Fatalf("ReadAnimeHistory: %v", err)
+	}
+	if n := len(history["animecix"]); n != writers {
+		t.Errorf("kayıt sayısı = %d, %d olmalı", n, writers)
+	}
+}
+
+func TestCompletionThreshold(t *testing.T) {
+	tests := []struct {
+		in       string
+		position float64
+		duration float64
+		want     bool
+	}{
+		{"", 1140, 1440, true}, // bitişe 300 saniye kala
+		{"", 1000, 1440, false},
+		{"", 100, 240, false}, // 4 dakikalık bölüm hemen izlendi sayılmaz
+		{"", 220, 240, true},  // kısa bölümde %90
+		{"80%", 1152, 1440, true},
+		{"%80", 1100, 1440, false},
+		{"60s", 1380, 1440, true},
+	}
+	for _, tt := range tests {
+		threshold, err := ParseCompletionThreshold(tt.in)
+		if err != nil {
+			t.Fatalf("ParseCompletionThreshold(%q): %v", tt.in, err)
+		}
+		if got := threshold.Reached(tt.position, tt.duration); got != tt.want {
+			t.Errorf("%q: Reached(%v, %v) = %v, %v olmalı", tt.in, tt.position, tt.duration, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"abc", "150%", "-10"} {
+		if _, err := ParseCompletionThreshold(bad); err == nil {
+			t.Errorf("ParseCompletionThreshold(%q) hata dönmeli", bad)
+		}
+	}
+}
+
+func TestHistoryExportRoundTrip(t *testing.T) {
+	last := 4
+	watched := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	history := AnimeHistory{
+		"animecix": {
+			"42": {
+				Title:           "Sousou no Frieren",
+				LastEpisodeIdx:  &last,
+				LastEpisodeName: "5. Bölüm",
+				LastWatched:     &watched,
+				Episodes:        map[string]EpisodeRecord{"4": {Position: 300, Duration: 1400, UpdatedAt: watched}},
+			},
+		},
+	}
+
+	exported := HistoryRecords(history)
+	exported[0].MALID, exported[0].AniListID = 52991, 154587
+
+	for _, format := range HistoryFormats {
+		var buf bytes.Buffer
+		if err := ExportHistory(&buf, exported, format); err != nil {
+			t.Fatalf("%s: ExportHistory: %v", format, err)
+		}
+		records, err := ParseHistoryExport(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: ParseHistoryExport: %v", format, err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("%s: kayıt sayısı = %d, 1 olmalı", format, len(records))
+		}
+
+		// Son bölüm yarıda bırakıldığı için izlenen bölüm sayısı 4'tür
+		rec := records[0]
+		if rec.Source != "animecix" || rec.AnimeID != "42" || rec.Title != "Sousou no Frieren" || rec.WatchedEpisodes != 4 {
+			t.Errorf("%s: kayıt yanlış: %+v", format, rec)
+		}
+		if rec.ListID(format) != exported[0].ListID(format) {
+			t.Errorf("%s: site ID'si = %d, %d olmalı", format, rec.ListID(format), exported[0].ListID(format))
+		}
+
+		seeded := make(AnimeHistory)
+		if !SeedHistory(seeded, rec) {
+			t.Fatalf("%s: kayıt geçmişe işlenmedi", format)
+		}
+		entry := seeded["animecix"]["42"]
+		if entry.LastEpisodeIdx == nil || *entry.LastEpisodeIdx != 3 || !entry.Episodes["3"].Watched {
+			t.Errorf("%s: işlenen kayıt yanlış: %+v", format, entry)
+		}
+	}
+}
+
+// seedTestHistory, geçici bir HOME altında history.json'u verilen geçmişle oluşturur
+func seedTestHistory(t *testing.T, history AnimeHistory) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("APPDATA", dir)
+	if err := WriteAnimeHistory(history); err != nil {
+		t.Fatalf("WriteAnimeHistory: %v", err)
+	}
+}
+
+// watchedRecords, verilen bölümler izlenmiş olarak bölüm kayıtlarını oluşturur
+func watchedRecords(indices ...int) map[string]EpisodeRecord {
+	records := make(map[string]EpisodeRecord)
+	for _, idx := range indices {
+		records[fmt.Sprint(idx)] = EpisodeRecord{Watched: true}
+	}
+	return records
+}
+
+// testEntry, son izlenen bölümü last (0'dan başlar, -1: yok) olan bir kayıt oluşturur
+func testEntry(last int, records map[string]EpisodeRecord) AnimeHistoryEntry {
+	entry := AnimeHistoryEntry{Title: "Frieren", Episodes: records}
+	if last >= 0 {
+		entry.LastEpisodeIdx = &last
+		entry.LastEpisodeName = fmt.Sprintf("%d. Bölüm", last+1)
+	}
+	return entry
+}
+
+// checkEntry, kaydın son izlenen bölümünü ve izlenmiş bölümlerini denetler
+func checkEntry(t *testing.T, entry AnimeHistoryEntry, wantLast int, wantName string, wantWatched []int) {
+	t.Helper()
+	switch {
+	case wantLast < 0 && entry.LastEpisodeIdx != nil:
+		t.Errorf("son izlenen = %d, boş olmalı", *entry.LastEpisodeIdx)
+	case wantLast >= 0 && (entry.LastEpisodeIdx == nil || *entry.LastEpisodeIdx != wantLast):
+		t.Errorf("son izlenen = %v, %d olmalı", entry.LastEpisodeIdx, wantLast)
+	}
+	if entry.LastEpisodeName != wantName {
+		t.Errorf("son izlenen adı = %q, %q olmalı", entry.LastEpisodeName, wantName)
+	}
+
+	var watched []int
+	for key, record := range entry.Episodes {
+		if record.Watched {
+			var idx int
+			fmt.Sscan(key, &idx)
+			watched = append(watched, idx)
+		}
+	}
+	slices.Sort(watched)
+	if !slices.Equal(watched, wantWatched) {
+		t.Errorf("izlenen bölümler = %v, %v olmalı", watched, wantWatched)
+	}
+}
+
+func TestMarkEpisodes(t *testing.T) {
+	names := []string{"1. Bölüm", "2. Bölüm", "3. Bölüm", "4. Bölüm", "5. Bölüm"}
+
+	tests := []struct {
+		name        string
+		entry       *AnimeHistoryEntry // nil: geçmişte yok
+		indices     []int
+		watched     bool
+		wantRemoved bool
+		wantLast    int // -1: son izlenen yok
+		wantName    string
+		wantWatched []int
+	}{
+		{
+			name:        "yeni anime izlendi",
+			indices:     []int{0, 1},
+			watched:     true,
+			wantLast:    1,
+			wantName:    "2. Bölüm",
+			wantWatched: []int{0, 1},
+		},
+		{
+			name:        "geriye izlendi işareti son bölümü değiştirmez",
+			entry:       Ptr(testEntry(3, watchedRecords(3))),
+			indices:     []int{1},
+			watched:     true,
+			wantLast:    3,
+			wantName:    "4. Bölüm",
+			wantWatched: []int{1, 3},
+		},
+		{
+			name:        "son bölüm izlenmedi, kalan en ileri bölüme geri alınır",
+			entry:       Ptr(testEntry(3, watchedRecords(0, 1, 2, 3))),
+			indices:     []int{3},
+			wantLast:    2,
+			wantName:    "3. Bölüm",
+			wantWatched: []int{0, 1, 2},
+		},
+		{
+			name:        "bölüm kaydı olmayan eski geçmişte ilk işaretlenenin öncesine geri alınır",
+			entry:       Ptr(testEntry(3, nil)),
+			indices:     []int{3, 2},
+			wantLast:    1,
+			wantName:    "2. Bölüm",
+			wantWatched: nil,
+		},
+		{
+			name:        "tüm bölümler izlenmedi, anime geçmişten çıkar",
+			entry:       Ptr(testEntry(0, watchedRecords(0))),
+			indices:     []int{0},
+			wantRemoved: true,
+		},
+		{
+			name:        "aralık dışındaki indeksler yok sayılır",
+			entry:       Ptr(testEntry(1, watchedRecords(0, 1))),
+			indices:     []int{-1, 7},
+			watched:     true,
+			wantLast:    1,
+			wantName:    "2. Bölüm",
+			wantWatched: []int{0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := AnimeHistory{}
+			if tt.entry != nil {
+				history["animecix"] = map[string]AnimeHistoryEntry{"42": *tt.entry}
+			}
+			seedTestHistory(t, history)
+
+			if err := MarkEpisodes("animecix", "42", "Frieren", names, tt.indices, tt.watched); err != nil {
+				t.Fatalf("MarkEpisodes: %v", err)
+			}
+			got, err := ReadAnimeHistory()
+			if err != nil {
+				t.Fatalf("ReadAnimeHistory: %v", err)
+			}
+
+			entry, ok := got["animecix"]["42"]
+			if tt.wantRemoved {
+				if ok {
+					t.Fatalf("anime geçmişten çıkmalı: %+v", entry)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("anime geçmişte yok")
+			}
+			checkEntry(t, entry, tt.wantLast, tt.wantName, tt.wantWatched)
+		})
+	}
+}
+
+func TestSetLastEpisode(t *testing.T) {
+	tests := []struct {
+		name        string
+		entry       AnimeHistoryEntry
+		n           int
+		epName      string
+		wantErr     bool
+		wantLast    int
+		wantName    string
+		wantWatched []int
+		wantRecords []string // kalan bölüm kayıtları
+	}{
+		{
+			name:        "sıfırlama ilk bölümü başlandı bırakır",
+			entry:       testEntry(3, watchedRecords(0, 1, 2, 3)),
+			n:           0,
+			wantLast:    0,
+			wantName:    "1. Bölüm",
+			wantWatched: nil,
+			wantRecords: []string{"0"},
+		},
+		{
+			name:        "geri alma ilerideki kayıtları siler",
+			entry:       testEntry(4, watchedRecords(0, 1, 2, 3, 4)),
+			n:           2,
+			wantLast:    1,
+			wantName:    "2. Bölüm",
+			wantWatched: []int{0, 1},
+			wantRecords: []string{"0", "1"},
+		},
+		{
+			name:        "ileri alma verilen adı kullanır",
+			entry:       testEntry(1, watchedRecords(0, 1)),
+			n:           5,
+			epName:      "Final",
+			wantLast:    4,
+			wantName:    "Final",
+			wantWatched: []int{0, 1, 4},
+			wantRecords: []string{"0", "1", "4"},
+		},
+		{
+			name:        "aynı bölümde ad korunur",
+			entry:       AnimeHistoryEntry{Title: "Frieren", LastEpisodeIdx: Ptr(2), LastEpisodeName: "Özel"},
+			n:           3,
+			wantLast:    2,
+			wantName:    "Özel",
+			wantWatched: []int{2},
+			wantRecords: []string{"2"},
+		},
+		{
+			name:    "negatif bölüm",
+			entry:   testEntry(1, nil),
+			n:       -1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedTestHistory(t, AnimeHistory{"animecix": {"42": tt.entry}})
+
+			err := SetLastEpisode("animecix", "42", tt.n, tt.epName)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("SetLastEpisode hata dönmeli")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetLastEpisode: %v", err)
+			}
+
+			got, err := ReadAnimeHistory()
+			if err != nil {
+				t.Fatalf("ReadAnimeHistory: %v", err)
+			}
+			entry := got["animecix"]["42"]
+			checkEntry(t, entry, tt.wantLast, tt.wantName, tt.wantWatched)
+
+			var records []string
+			for key := range entry.Episodes {
+				records = append(records, key)
+			}
+			slices.Sort(records)
+			if !slices.Equal(records, tt.wantRecords) {
+				t.Errorf("bölüm kayıtları = %v, %v olmalı", records, tt.wantRecords)
+			}
+		})
+	}
+
+	t.Run("geçmişte olmayan anime", func(t *testing.T) {
+		seedTestHistory(t, AnimeHistory{})
+		if err := SetLastEpisode("animecix", "42", 1, ""); err == nil {
+			t.Fatal("SetLastEpisode hata dönmeli")
+		}
+	})
+}
+
+func TestRemoveAnimeHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		animeId     string
+		wantErr     bool
+		wantSources []string
+	}{
+		{name: "kaynakta başka anime kalır", source: "animecix", animeId: "42", wantSources: []string{"animecix", "openanime"}},
+		{name: "kaynağın son animesi kaynağı da siler", source: "openanime", animeId: "frieren", wantSources: []string{"animecix"}},
+		{name: "olmayan anime", source: "openanime", animeId: "42", wantErr: true, wantSources: []string{"animecix", "openanime"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedTestHistory(t, AnimeHistory{
+				"animecix":  {"42": testEntry(0, nil), "7": testEntry(1, nil)},
+				"openanime": {"frieren": testEntry(2, nil)},
+			})
+
+			err := RemoveAnimeHistory(tt.source, tt.animeId)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RemoveAnimeHistory hata = %v, hata beklenmesi %v", err, tt.wantErr)
+			}
+
+			got, err := ReadAnimeHistory()
+			if err != nil {
+				t.Fatalf("ReadAnimeHistory: %v", err)
+			}
+			if _, ok := got[tt.source][tt.animeId]; ok {
+				t.Errorf("%s/%s geçmişte kalmamalı", tt.source, tt.animeId)
+			}
+			if sources := slices.Sorted(maps.Keys(got)); !slices.Equal(sources, tt.wantSources) {
+				t.Errorf("kaynaklar = %v, %v olmalı", sources, tt.wantSources)
+			}
+		})
+	}
+}
+
+func TestClearAnimeHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		wantRemoved int
+		wantSources []string
+	}{
+		{name: "tek kaynak", source: "animecix", wantRemoved: 2, wantSources: []string{"openanime"}},
+		{name: "tüm kaynaklar", source: "", wantRemoved: 3, wantSources: []string{}},
+		{name: "geçmişi olmayan kaynak", source: "yok", wantRemoved: 0, wantSources: []string{"animecix", "openanime"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedTestHistory(t, AnimeHistory{
+				"animecix":  {"42": testEntry(0, nil), "7": testEntry(1, nil)},
+				"openanime": {"frieren": testEntry(2, nil)},
+			})
+
+			removed, err := ClearAnimeHistory(tt.source)
+			if err != nil {
+				t.Fatalf("ClearAnimeHistory: %v", err)
+			}
+			if removed != tt.wantRemoved {
+				t.Errorf("silinen = %d, %d olmalı", removed, tt.wantRemoved)
+			}
+
+			got, err := ReadAnimeHistory()
+			if err != nil {
+				t.Fatalf("ReadAnimeHistory: %v", err)
+			}
+			if sources := slices.Sorted(maps.Keys(got)); !slices.Equal(sources, tt.wantSources) {
+				t.Errorf("kaynaklar = %v, %v olmalı", sources, tt.wantSources)
+			}
+		})
+	}
+}
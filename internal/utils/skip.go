@@ -0,0 +1,370 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axrona/anitr-cli/internal/player"
+)
+
+// SkipRange, atlanacak bir aralıktır (saniye cinsinden)
+type SkipRange struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// AnimeSkips, bir anime için kaydedilmiş intro ve outro aralıklarıdır
+type AnimeSkips struct {
+	Title string     `json:"title,omitempty"` // Animenin görünen adı
+	Intro *SkipRange `json:"intro,omitempty"`
+	Outro *SkipRange `json:"outro,omitempty"`
+}
+
+// Skips, source -> anime ID'si (slug kullanan kaynaklarda slug) -> atlama aralıkları.
+// Anahtarlar geçmişle (AnimeHistory) aynıdır.
+type Skips map[string]map[string]AnimeSkips
+
+// skipsVersion, skips.json'un güncel şema sürümüdür.
+// Sürüm alanı olmayan dosyalar v1 (anime adıyla anahtarlanan) kabul edilir.
+const skipsVersion = 2
+
+// skipsFile, skips.json'un diskteki biçimidir
+type skipsFile struct {
+	Version int   `json:"version"`
+	Sources Skips `json:"sources"`
+}
+
+// Atlama türleri
+const (
+	SkipIntro = "intro"
+	SkipOutro = "outro"
+)
+
+// Bölüm işaretlerinde intro ve outro olarak kabul edilen başlıklar
+var (
+	introChapterRe = regexp.MustCompile(`(?i)^\s*(op|opening|intro)\b`)
+	outroChapterRe = regexp.MustCompile(`(?i)^\s*(ed|ending|outro|credits)\b`)
+)
+
+// getSkipsPath, skips.json yolunu döndürür
+func getSkipsPath() (string, error) {
+	dir := ConfigDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("config klasörü oluşturulamadı: %w", err)
+	}
+	return filepath.Join(dir, "skips.json"), nil
+}
+
+// ReadSkips, skips.json'u okur, yoksa boş döner.
+// Eski (sürümsüz, anime adıyla anahtarlanan) biçimdeki dosya ilk okumada
+// yedeklenip güncel biçime dönüştürülür.
+func ReadSkips() (Skips, error) {
+	path, err := getSkipsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var skips Skips
+	err = withFileLock(path, func() error {
+		skips, err = readSkipsFile(path)
+		return err
+	})
+	return skips, err
+}
+
+// ModifySkips, skips.json'u kilit altında okur, fn ile değiştirir ve yazar.
+// fn hata dönerse dosya yazılmaz.
+func ModifySkips(fn func(Skips) error) error {
+	path, err := getSkipsPath()
+	if err != nil {
+		return err
+	}
+	return withFileLock(path, func() error {
+		skips, err := readSkipsFile(path)
+		if err != nil {
+			return err
+		}
+		if err := fn(skips); err != nil {
+			return err
+		}
+		return writeSkipsFile(path, skips)
+	})
+}
+
+// readSkipsFile, skips.json'u okur. Kilit tutulurken çağrılır.
+func readSkipsFile(path string) (Skips, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(Skips), nil
+		}
+		return nil, fmt.Errorf("skips okunamadı: %w", err)
+	}
+
+	var file skipsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("skips parse edilemedi: %w", err)
+	}
+
+	switch {
+	case file.Version == 0:
+		return migrateSkipsFile(path, data)
+	case file.Version > skipsVersion:
+		return nil, fmt.Errorf("skips sürümü desteklenmiyor: %d (anitr-cli'yi güncelleyin)", file.Version)
+	}
+
+	if file.Sources == nil {
+		file.Sources = make(Skips)
+	}
+	return file.Sources, nil
+}
+
+// writeSkipsFile, skips.json'u atomik olarak yazar. Kilit tutulurken çağrılır.
+func writeSkipsFile(path string, skips Skips) error {
+	data, err := json.MarshalIndent(skipsFile{Version: skipsVersion, Sources: skips}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("skips serialize edilemedi: %w", err)
+	}
+	if err := WriteFileAtomic(path, data, 0o644); err != nil {
+		return fmt.Errorf("skips yazılamadı: %w", err)
+	}
+	return nil
+}
+
+// GetAnimeSkips, animenin kayıtlı atlama aralıklarını döner
+func GetAnimeSkips(source, animeId string) AnimeSkips {
+	skips, err := ReadSkips()
+	if err != nil {
+		return AnimeSkips{}
+	}
+	return skips[source][animeId]
+}
+
+// SetAnimeSkip, animenin intro ya da outro aralığını kaydeder. r nil ise aralık silinir.
+// title, kaydın okunabilir kalması için anime adıyla birlikte saklanır.
+func SetAnimeSkip(source, animeId, title, kind string, r *SkipRange) error {
+	if source == "" || animeId == "" {
+		return fmt.Errorf("atlama aralığı kaydedilemedi: anime ID'si bilinmiyor")
+	}
+
+	return ModifySkips(func(skips Skips) error {
+		sourceEntry, ok := skips[source]
+		if !ok {
+			sourceEntry = make(map[string]AnimeSkips)
+		}
+
+		entry := sourceEntry[animeId]
+		switch kind {
+		case SkipIntro:
+			entry.Intro = r
+		case SkipOutro:
+			entry.Outro = r
+		default:
+			return fmt.Errorf("geçersiz atlama türü: %s", kind)
+		}
+		if title != "" {
+			entry.Title = title
+		}
+
+		if entry.Intro == nil && entry.Outro == nil {
+			delete(sourceEntry, animeId)
+		} else {
+			sourceEntry[animeId] = entry
+		}
+		if len(sourceEntry) == 0 {
+			delete(skips, source)
+		} else {
+			skips[source] = sourceEntry
+		}
+		return nil
+	})
+}
+
+// parseClock, "ss:dd:ss", "dd:ss" ya da saniye biçimindeki zamanı saniyeye çevirir
+func parseClock(s string) (float64, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("geçersiz zaman: %s", s)
+	}
+
+	total := 0.0
+	for _, p := range parts {
+		n, err := strconv.ParseFloat(p, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("geçersiz zaman: %s", s)
+		}
+		total = total*60 + n
+	}
+	return total, nil
+}
+
+// ParseSkipRange, "01:30-03:00" biçimindeki aralığı çözümler
+func ParseSkipRange(s string) (*SkipRange, error) {
+	start, end, ok := strings.Cut(strings.ReplaceAll(s, "–", "-"), "-")
+	if !ok {
+		return nil, fmt.Errorf("aralık dd:ss-dd:ss biçiminde olmalı: %s", s)
+	}
+
+	r := &SkipRange{}
+	var err error
+	if r.Start, err = parseClock(start); err != nil {
+		return nil, err
+	}
+	if r.End, err = parseClock(end); err != nil {
+		return nil, err
+	}
+	if r.End <= r.Start {
+		return nil, fmt.Errorf("bitiş başlangıçtan sonra olmalı: %s", s)
+	}
+	return r, nil
+}
+
+// chapterSkips, bölüm işaretlerinden OP/ED aralıklarını çıkarır.
+// Bir işaretin bitişi sonraki işaretin başlangıcı, son işaret için video süresidir.
+func chapterSkips(chapters []player.Chapter, duration float64) AnimeSkips {
+	var skips AnimeSkips
+	for i, ch := range chapters {
+		end := duration
+		if i+1 < len(chapters) {
+			end = chapters[i+1].Time
+		}
+		if end <= ch.Time {
+			continue
+		}
+
+		r := &SkipRange{Start: ch.Time, End: end}
+		switch {
+		case skips.Intro == nil && introChapterRe.MatchString(ch.Title):
+			skips.Intro = r
+		case skips.Outro == nil && outroChapterRe.MatchString(ch.Title):
+			skips.Outro = r
+		}
+	}
+	return skips
+}
+
+// Intro/outro işaretleme tuşları ve mesajı
+const (
+	skipMarkMessage = "anitr-mark"
+	introMarkKey    = "alt+i"
+	outroMarkKey    = "alt+o"
+)
+
+// AutoSkip, oynatma sırasında intro ve outro aralıklarını atlar.
+// Video OP/ED adlı bölüm işaretleri içeriyorsa onlar, yoksa anime için kaydedilmiş
+// aralıklar kullanılır. Her aralık bir videoda yalnızca bir kez atlanır; geri sarılırsa tekrar atlanmaz.
+// Oynatma sırasında Alt+I / Alt+O ile aralığın başı ve sonu işaretlenip kaydedilebilir.
+// Yalnızca mpv tabanlı oynatıcılarda çalışır.
+func AutoSkip(p player.Player, source, animeId, title string, logger *Logger) {
+	mpv, ok := p.(*player.MPV)
+	if !ok {
+		return
+	}
+
+	for key, kind := range map[string]string{introMarkKey: SkipIntro, outroMarkKey: SkipOutro} {
+		if err := mpv.BindKey(key, fmt.Sprintf("script-message %s %s", skipMarkMessage, kind)); err != nil {
+			logger.LogError(fmt.Errorf("tuş ataması yapılamadı: %w", err))
+		}
+	}
+
+	saved := GetAnimeSkips(source, animeId)
+	active := saved
+	chaptersLoaded := false
+	skipped := make(map[string]bool)
+	marks := make(map[string]float64) // işaretlenen aralık başlangıçları
+
+	events, cancel := player.Subscribe(p)
+	defer cancel()
+
+	for ev := range events {
+		st := ev.State
+
+		switch ev.Type {
+		case player.EventPlaylistPos:
+			// Yeni videoda bölüm işaretleri yeniden okunur
+			active = saved
+			chaptersLoaded = false
+			skipped = make(map[string]bool)
+			continue
+
+		case player.EventMessage:
+			if len(ev.Message) < 2 || ev.Message[0] != skipMarkMessage {
+				continue
+			}
+			kind := ev.Message[1]
+			start, marking := marks[kind]
+			if !marking {
+				marks[kind] = st.Position
+				_ = mpv.ShowText(fmt.Sprintf("%s başlangıcı: %s (bitiş için tekrar basın)", kind, clock(st.Position)), 3*time.Second)
+				continue
+			}
+			delete(marks, kind)
+
+			r := &SkipRange{Start: start, End: st.Position}
+			if r.End <= r.Start {
+				_ = mpv.ShowText(fmt.Sprintf("%s bitişi başlangıçtan önce olamaz", kind), 3*time.Second)
+				continue
+			}
+			if err := SetAnimeSkip(source, animeId, title, kind, r); err != nil {
+				logger.LogError(err)
+				_ = mpv.ShowText(fmt.Sprintf("%s kaydedilemedi", kind), 3*time.Second)
+				continue
+			}
+			if kind == SkipIntro {
+				saved.Intro, active.Intro = r, r
+			} else {
+				saved.Outro, active.Outro = r, r
+			}
+			skipped[kind] = true // az önce izlenen aralık bu videoda tekrar atlanmaz
+			_ = mpv.ShowText(fmt.Sprintf("%s kaydedildi: %s-%s", kind, clock(r.Start), clock(r.End)), 3*time.Second)
+			continue
+		}
+
+		// Süre öğrenildiğinde video yüklenmiştir; bölüm işaretleri okunur
+		if !chaptersLoaded && st.Duration > 0 {
+			chaptersLoaded = true
+			if chapters, err := mpv.Chapters(); err == nil {
+				fromChapters := chapterSkips(chapters, st.Duration)
+				if fromChapters.Intro != nil {
+					active.Intro = fromChapters.Intro
+				}
+				if fromChapters.Outro != nil {
+					active.Outro = fromChapters.Outro
+				}
+			}
+		}
+
+		if ev.Type != player.EventPosition {
+			continue
+		}
+
+		for kind, r := range map[string]*SkipRange{SkipIntro: active.Intro, SkipOutro: active.Outro} {
+			if r == nil || skipped[kind] {
+				continue
+			}
+			// Aralığın son saniyesinde atlamaya gerek yok
+			if st.Position < r.Start || st.Position >= r.End-1 {
+				continue
+			}
+			skipped[kind] = true
+			if _, err := player.SeekMPV(mpv.SocketPath(), int(r.End)); err != nil && !errors.Is(err, player.ErrIPCClosed) {
+				logger.LogError(fmt.Errorf("%s atlanamadı: %w", kind, err))
+				continue
+			}
+			_ = mpv.ShowText(fmt.Sprintf("%s atlandı", kind), 2*time.Second)
+		}
+	}
+}
+
+// clock, saniyeyi "dd:ss" biçiminde yazar
+func clock(seconds float64) string {
+	total := int(seconds)
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// migrateSkipsFile, v1 skips.json'u yedekler, güncel biçime dönüştürüp yerine yazar.
+// Yedek path + ".v1.bak" olarak bir kez alınır; varsa üzerine yazılmaz. Kilit tutulurken çağrılır.
+func migrateSkipsFile(path string, data []byte) (Skips, error) {
+	history, err := ReadAnimeHistory()
+	if err != nil {
+		return nil, err
+	}
+	skips, err := migrateSkipsV1(data, history)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := path + ".v1.bak"
+	if _, err := os.Stat(backup); os.IsNotExist(err) {
+		if err := WriteFileAtomic(backup, data, 0o644); err != nil {
+			return nil, fmt.Errorf("skips yedeği alınamadı: %w", err)
+		}
+	}
+
+	if err := writeSkipsFile(path, skips); err != nil {
+		return nil, err
+	}
+	return skips, nil
+}
+
+// migrateSkipsV1, source -> anime adı -> aralıklar biçimindeki eski kayıtları
+// source -> anime ID'si -> aralıklar biçimine çevirir. ID'ler geçmişte aynı kaynakta
+// aynı adla (büyük/küçük harf farkı gözetmeden) kayıtlı animelerden bulunur; ad birden
+// fazla animeyle eşleşirse aralıklar, eskiden olduğu gibi hepsine uygulanır.
+// Geçmişte karşılığı olmayan kayıtlar taşınmaz, yalnızca yedekte kalır.
+func migrateSkipsV1(data []byte, history AnimeHistory) (Skips, error) {
+	var legacy map[string]map[string]AnimeSkips
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, fmt.Errorf("eski skips parse edilemedi: %w", err)
+	}
+
+	skips := make(Skips)
+	for source, animes := range legacy {
+		// Geçmişteki anime adı -> ID'ler
+		ids := make(map[string][]string)
+		for animeId, entry := range history[source] {
+			title := strings.ToLower(strings.TrimSpace(entry.Title))
+			ids[title] = append(ids[title], animeId)
+		}
+
+		sourceEntry := make(map[string]AnimeSkips)
+		for animeName, old := range animes {
+			if old.Intro == nil && old.Outro == nil {
+				continue
+			}
+			old.Title = animeName
+			for _, animeId := range ids[strings.ToLower(strings.TrimSpace(animeName))] {
+				sourceEntry[animeId] = old
+			}
+		}
+		if len(sourceEntry) > 0 {
+			skips[source] = sourceEntry
+		}
+	}
+	return skips, nil
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSkipsMigratesV1(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("APPDATA", dir)
+
+	// Aynı adlı iki anime, geçmişte olmayan bir anime ve slug kullanan bir kaynak
+	err := WriteAnimeHistory(AnimeHistory{
+		"animecix": {
+			"42": {Title: "Frieren"},
+			"43": {Title: "frieren "},
+			"7":  {Title: "Mushishi"},
+		},
+		"openanime": {
+			"sousou-no-frieren": {Title: "Sousou no Frieren"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteAnimeHistory: %v", err)
+	}
+
+	legacy := `{
+  "animecix": {
+    "Frieren": {"intro": {"start": 90, "end": 180}},
+    "Geçmişte Yok": {"outro": {"start": 1300, "end": 1390}}
+  },
+  "openanime": {
+    "Sousou no Frieren": {"intro": {"start": 0, "end": 85}, "outro": {"start": 1320, "end": 1410}}
+  }
+}`
+	path := filepath.Join(ConfigDir(), "skips.json")
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	skips, err := ReadSkips()
+	if err != nil {
+		t.Fatalf("ReadSkips: %v", err)
+	}
+
+	if n := len(skips["animecix"]); n != 2 {
+		t.Fatalf("animecix kayıt sayısı = %d, 2 olmalı: %+v", n, skips["animecix"])
+	}
+	for _, id := range []string{"42", "43"} {
+		entry := skips["animecix"][id]
+		if entry.Title != "Frieren" || entry.Intro == nil || entry.Intro.Start != 90 || entry.Outro != nil {
+			t.Errorf("animecix/%s kaydı yanlış: %+v", id, entry)
+		}
+	}
+	if entry := GetAnimeSkips("openanime", "sousou-no-frieren"); entry.Intro == nil || entry.Outro == nil {
+		t.Errorf("openanime kaydı slug ile anahtarlanmadı: %+v", skips["openanime"])
+	}
+
+	// Eski dosya yedeklenmeli
+	backup, err := os.ReadFile(path + ".v1.bak")
+	if err != nil || string(backup) != legacy {
+		t.Errorf("yedek alınmadı ya da farklı: %v", err)
+	}
+
+	// Dosya güncel sürümle yeniden yazılmış olmalı
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file skipsFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != skipsVersion {
+		t.Errorf("dosya sürümü = %d, %d olmalı (hata: %v)", file.Version, skipsVersion, err)
+	}
+}
+
+func TestSetAnimeSkip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("APPDATA", dir)
+
+	intro := &SkipRange{Start: 90, End: 180}
+	if err := SetAnimeSkip("animecix", "42", "Frieren", SkipIntro, intro); err != nil {
+		t.Fatalf("SetAnimeSkip: %v", err)
+	}
+	// Aynı adlı başka bir anime kendi kaydını kullanır
+	if got := GetAnimeSkips("animecix", "43"); got.Intro != nil {
+		t.Errorf("animecix/43 aralığı = %+v, boş olmalı", got.Intro)
+	}
+	if got := GetAnimeSkips("animecix", "42"); got.Title != "Frieren" || got.Intro == nil || *got.Intro != *intro {
+		t.Errorf("animecix/42 = %+v", got)
+	}
+
+	// Son aralık silinince anime ve kaynak da silinir
+	if err := SetAnimeSkip("animecix", "42", "", SkipIntro, nil); err != nil {
+		t.Fatalf("SetAnimeSkip: %v", err)
+	}
+	skips, err := ReadSkips()
+	if err != nil {
+		t.Fatalf("ReadSkips: %v", err)
+	}
+	if len(skips) != 0 {
+		t.Errorf("skips boş olmalı: %+v", skips)
+	}
+}
@@ -1,10 +1,10 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/axrona/anitr-cli/internal"
+	"github.com/axrona/anitr-cli/internal/httpx"
 	"github.com/axrona/anitr-cli/internal/ui"
 )
 
@@ -108,14 +109,8 @@ func CheckErr(params internal.UiParams, err error, logger *Logger) bool {
 }
 
 // IsValidImage, verilen URL'nin geçerli bir görsel olup olmadığını kontrol eder.
-func IsValidImage(url string) bool {
-	client := &http.Client{}
-	req, err := http.NewRequest("HEAD", url, nil)
-	if err != nil {
-		return false
-	}
-
-	resp, err := client.Do(req)
+func IsValidImage(ctx context.Context, url string) bool {
+	resp, err := httpx.Head(ctx, url, nil)
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WatchlistEntry, izleme listesine eklenen bir animenin bilgileridir
+type WatchlistEntry struct {
+	Title   string    `json:"title"`             // Animenin görünen adı
+	Poster  string    `json:"poster,omitempty"`  // Poster URL'si
+	IsMovie bool      `json:"isMovie,omitempty"` // Film ise yeni bölüm aranmaz
+	AddedAt time.Time `json:"addedAt"`           // Listeye eklendiği zaman
+}
+
+// Watchlist, source -> anime ID'si (slug kullanan kaynaklarda slug) -> kayıt.
+// Anahtarlar geçmişle (AnimeHistory) aynıdır; böylece son izlenen bölüm geçmişten bulunur.
+type Watchlist map[string]map[string]WatchlistEntry
+
+// watchlistVersion, watchlist.json'un güncel şema sürümüdür
+const watchlistVersion = 1
+
+// watchlistFile, watchlist.json'un diskteki biçimidir
+type watchlistFile struct {
+	Version int       `json:"version"`
+	Sources Watchlist `json:"sources"`
+}
+
+// getWatchlistPath, watchlist.json yolunu döndürür
+func getWatchlistPath() (string, error) {
+	dir := ConfigDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("izleme listesi klasörü oluşturulamadı: %w", err)
+	}
+	return filepath.Join(dir, "watchlist.json"), nil
+}
+
+// ReadWatchlist, watchlist.json'u okur; dosya yoksa boş liste döner
+func ReadWatchlist() (Watchlist, error) {
+	path, err := getWatchlistPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var list Watchlist
+	err = withFileLock(path, func() error {
+		list, err = readWatchlistFile(path)
+		return err
+	})
+	return list, err
+}
+
+// ModifyWatchlist, watchlist.json'u kilit altında okur, fn ile değiştirir ve yazar.
+// fn hata dönerse dosya yazılmaz.
+func ModifyWatchlist(fn func(Watchlist) error) error {
+	path, err := getWatchlistPath()
+	if err != nil {
+		return err
+	}
+	return withFileLock(path, func() error {
+		list, err := readWatchlistFile(path)
+		if err != nil {
+			return err
+		}
+		if err := fn(list); err != nil {
+			return err
+		}
+		return writeWatchlistFile(path, list)
+	})
+}
+
+// readWatchlistFile, watchlist.json'u okur. Kilit tutulurken çağrılır.
+func readWatchlistFile(path string) (Watchlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(Watchlist), nil
+		}
+		return nil, fmt.Errorf("izleme listesi okunamadı: %w", err)
+	}
+
+	var file watchlistFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("izleme listesi parse edilemedi: %w", err)
+	}
+	if file.Version > watchlistVersion {
+		return nil, fmt.Errorf("izleme listesi sürümü desteklenmiyor: %d (anitr-cli'yi güncelleyin)", file.Version)
+	}
+	if file.Sources == nil {
+		file.Sources = make(Watchlist)
+	}
+	return file.Sources, nil
+}
+
+// writeWatchlistFile, watchlist.json'u atomik olarak yazar. Kilit tutulurken çağrılır.
+func writeWatchlistFile(path string, list Watchlist) error {
+	data, err := json.MarshalIndent(watchlistFile{Version: watchlistVersion, Sources: list}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("izleme listesi serialize edilemedi: %w", err)
+	}
+	if err := WriteFileAtomic(path, data, 0o644); err != nil {
+		return fmt.Errorf("izleme listesi yazılamadı: %w", err)
+	}
+	return nil
+}
+
+// AddToWatchlist, animeyi izleme listesine ekler; zaten listedeyse bilgilerini günceller
+// ve eklenme zamanını korur
+func AddToWatchlist(source, animeId string, entry WatchlistEntry) error {
+	if source == "" || animeId == "" {
+		return fmt.Errorf("izleme listesine eklenemedi: anime ID'si bilinmiyor")
+	}
+
+	return ModifyWatchlist(func(list Watchlist) error {
+		sourceEntry, ok := list[source]
+		if !ok {
+			sourceEntry = make(map[string]WatchlistEntry)
+		}
+		if existing, ok := sourceEntry[animeId]; ok && !existing.AddedAt.IsZero() {
+			entry.AddedAt = existing.AddedAt
+		}
+		if entry.AddedAt.IsZero() {
+			entry.AddedAt = time.Now()
+		}
+		sourceEntry[animeId] = entry
+		list[source] = sourceEntry
+		return nil
+	})
+}
+
+// RemoveFromWatchlist, animeyi izleme listesinden çıkarır
+func RemoveFromWatchlist(source, animeId string) error {
+	return ModifyWatchlist(func(list Watchlist) error {
+		if _, ok := list[source][animeId]; !ok {
+			return fmt.Errorf("izleme listesinde bulunamadı: %s/%s", source, animeId)
+		}
+		delete(list[source], animeId)
+		if len(list[source]) == 0 {
+			delete(list, source)
+		}
+		return nil
+	})
+}
+
+// InWatchlist, animenin izleme listesinde olup olmadığını döner
+func InWatchlist(source, animeId string) bool {
+	list, err := ReadWatchlist()
+	if err != nil {
+		return false
+	}
+	_, ok := list[source][animeId]
+	return ok
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package utils
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile, dosya üzerinde LockFileEx ile özel kilit alır; kilit boşalana kadar bekler
+func lockFile(f *os.File) error {
+	var ol windows.Overlapped
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &ol)
+}
+
+// unlockFile, lockFile ile alınan kilidi bırakır
+func unlockFile(f *os.File) error {
+	var ol windows.Overlapped
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &ol)
+}
+
+// syncDir, Windows'ta klasörler fsync edilemediği için bir şey yapmaz
+func syncDir(dir string) {}
@@ -0,0 +1,110 @@
+// Package watchlist, izleme listesindeki animelerin yeni bölümlerini denetler.
+// Liste utils.Watchlist olarak saklanır; her anime için kaynaktaki bölüm sayısı
+// geçmişteki son izlenen bölümle karşılaştırılır.
+package watchlist
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/axrona/anitr-cli/internal/cache"
+	"github.com/axrona/anitr-cli/internal/sources"
+	"github.com/axrona/anitr-cli/internal/utils"
+)
+
+// Status, izleme listesindeki bir animenin denetim sonucudur
+type Status struct {
+	Source      string // Kaynak kimliği
+	AnimeID     string // Kaynaktaki anime ID'si ya da slug'ı
+	Title       string // Anime adı
+	Episodes    int    // Kaynaktaki bölüm sayısı
+	LastWatched int    // Son izlenen bölüm numarası (1'den başlar, 0: hiç izlenmedi)
+	NewEpisodes int    // Son izlenen bölümden sonra yayınlanmış bölüm sayısı
+	Err         error  // Bölümler alınamadıysa hata
+}
+
+// Check, listedeki her anime için bölüm listesini önbelleği atlayarak kaynaktan yeniden alır
+// ve geçmişteki son izlenen bölümden sonraki bölümleri sayar; filmlerde yeni bölüm aranmaz.
+// Süresi dolmuş listelerin arka planda yenilenmesi tek seferlik denetimde tamamlanamayacağı
+// için önbellekteki kayıtlar kullanılmaz, alınan listeler önbelleğe yazılır. İstekler
+// sources.Workers() kadar paralel yapılır; alınamayan animelerin hatası Status.Err'de
+// döner. Sonuçlar yeni bölümü olanlar önde olacak şekilde ada göre sıralıdır.
+func Check(ctx context.Context, list utils.Watchlist, history utils.AnimeHistory) ([]Status, error) {
+	var statuses []Status
+	for source, animes := range list {
+		for animeId, entry := range animes {
+			st := Status{Source: source, AnimeID: animeId, Title: entry.Title}
+			if h, ok := history[source][animeId]; ok && h.LastEpisodeIdx != nil {
+				st.LastWatched = *h.LastEpisodeIdx + 1
+			}
+			statuses = append(statuses, st)
+		}
+	}
+
+	ctx = cache.WithRefresh(ctx)
+	results, err := sources.FetchAll(ctx, len(statuses), func(ctx context.Context, i int) (Status, error) {
+		st := statuses[i]
+		count, isMovie, err := episodeCount(ctx, st.Source, st.AnimeID, list[st.Source][st.AnimeID])
+		if err != nil {
+			// Tek bir animenin hatası diğerlerinin denetimini durdurmaz
+			st.Err = err
+			return st, nil
+		}
+		st.Episodes = count
+		if !isMovie {
+			st.NewEpisodes = max(count-st.LastWatched, 0)
+		}
+		return st, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if (results[i].NewEpisodes > 0) != (results[j].NewEpisodes > 0) {
+			return results[i].NewEpisodes > 0
+		}
+		return results[i].Title < results[j].Title
+	})
+	return results, nil
+}
+
+// episodeCount, animenin kaynaktaki bölüm sayısını ve film olup olmadığını döner
+func episodeCount(ctx context.Context, sourceID, animeId string, entry utils.WatchlistEntry) (int, bool, error) {
+	info, ok := sources.Get(sourceID)
+	if !ok {
+		return 0, false, fmt.Errorf("bilinmeyen kaynak: %s", sourceID)
+	}
+
+	var (
+		id   int
+		slug string
+	)
+	if info.Capabilities.Slug {
+		slug = animeId
+	} else {
+		var err error
+		if id, err = strconv.Atoi(animeId); err != nil {
+			return 0, false, fmt.Errorf("geçersiz anime ID'si: %s", animeId)
+		}
+	}
+
+	episodes, isMovie, err := sources.LoadEpisodes(ctx, info.Source, id, slug, entry.Title, entry.IsMovie)
+	if err != nil {
+		return 0, false, err
+	}
+	return len(episodes), isMovie, nil
+}
+
+// NewEpisodeCount, yeni bölümü olan anime sayısını döner
+func NewEpisodeCount(statuses []Status) int {
+	n := 0
+	for _, st := range statuses {
+		if st.NewEpisodes > 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,140 @@
+package watchlist
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/axrona/anitr-cli/internal/cache"
+	"github.com/axrona/anitr-cli/internal/models"
+	"github.com/axrona/anitr-cli/internal/sources"
+	"github.com/axrona/anitr-cli/internal/utils"
+)
+
+// fakeSource, bölüm sayısı testte değiştirilebilen ve bölüm listesini gerçek
+// kaynaklar gibi cache.FetchStale ile önbelleğe alan bir kaynaktır
+type fakeSource struct {
+	episodes atomic.Int32
+}
+
+func (s *fakeSource) GetSearchData(ctx context.Context, query string) ([]models.Anime, error) {
+	return nil, nil
+}
+
+func (s *fakeSource) GetAnimeByID(ctx context.Context, id string) (*models.Anime, error) {
+	return nil, nil
+}
+
+func (s *fakeSource) GetEpisodesData(ctx context.Context, params models.EpisodeParams) ([]models.Episode, error) {
+	key := cache.Key{Source: s.Source(), Endpoint: fmt.Sprintf("episodes/%d", *params.SeasonID)}
+	return cache.FetchStale(ctx, key, cache.EpisodesTTL, cache.EpisodesMaxStale, func(ctx context.Context) ([]models.Episode, error) {
+		episodes := make([]models.Episode, s.episodes.Load())
+		for i := range episodes {
+			episodes[i] = models.Episode{Number: i + 1, Title: fmt.Sprintf("%d. Bölüm", i+1)}
+		}
+		return episodes, nil
+	})
+}
+
+func (s *fakeSource) GetWatchData(ctx context.Context, params models.WatchParams) ([]models.Watch, error) {
+	return nil, nil
+}
+
+func (s *fakeSource) Source() string {
+	return "watchlisttest"
+}
+
+var fake = &fakeSource{}
+
+func init() {
+	sources.Register(sources.Info{ID: "watchlisttest", Source: fake})
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("APPDATA", dir)
+	cache.SetDir(t.TempDir())
+	cache.SetEnabled(true)
+	t.Cleanup(func() { cache.SetDir("") })
+
+	list := utils.Watchlist{"watchlisttest": {
+		"1": {Title: "Frieren"},
+		"2": {Title: "Mushishi"},
+	}}
+
+	check := func(t *testing.T) map[string]Status {
+		t.Helper()
+		history, err := utils.ReadAnimeHistory()
+		if err != nil {
+			t.Fatalf("ReadAnimeHistory: %v", err)
+		}
+		statuses, err := Check(context.Background(), list, history)
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		byID := make(map[string]Status)
+		for _, st := range statuses {
+			if st.Err != nil {
+				t.Fatalf("%s: %v", st.Title, st.Err)
+			}
+			byID[st.AnimeID] = st
+		}
+		return byID
+	}
+
+	// watch, animenin son izlenen bölümünü n. bölüm (1'den başlar) yapar
+	watch := func(t *testing.T, animeId string, n int) {
+		t.Helper()
+		err := utils.ModifyAnimeHistory(func(h utils.AnimeHistory) error {
+			if h["watchlisttest"] == nil {
+				h["watchlisttest"] = make(map[string]utils.AnimeHistoryEntry)
+			}
+			idx := n - 1
+			entry := h["watchlisttest"][animeId]
+			entry.LastEpisodeIdx = &idx
+			h["watchlisttest"][animeId] = entry
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ModifyAnimeHistory: %v", err)
+		}
+	}
+
+	fake.episodes.Store(3)
+	watch(t, "1", 1)
+
+	got := check(t)
+	if st := got["1"]; st.Episodes != 3 || st.LastWatched != 1 || st.NewEpisodes != 2 {
+		t.Errorf("Frieren = %+v, want 3 bölüm, son izlenen 1, 2 yeni", st)
+	}
+	if st := got["2"]; st.LastWatched != 0 || st.NewEpisodes != 3 {
+		t.Errorf("Mushishi = %+v, want son izlenen 0, 3 yeni", st)
+	}
+
+	// Yeni bölümler yayınlanır ve izlenir; önbellekteki taze liste kullanılmamalı
+	fake.episodes.Store(5)
+	watch(t, "1", 5)
+	watch(t, "2", 3)
+
+	got = check(t)
+	if st := got["1"]; st.Episodes != 5 || st.LastWatched != 5 || st.NewEpisodes != 0 {
+		t.Errorf("Frieren = %+v, want 5 bölüm, son izlenen 5, yeni yok", st)
+	}
+	if st := got["2"]; st.Episodes != 5 || st.LastWatched != 3 || st.NewEpisodes != 2 {
+		t.Errorf("Mushishi = %+v, want 5 bölüm, son izlenen 3, 2 yeni", st)
+	}
+	if n := NewEpisodeCount(mapValues(got)); n != 1 {
+		t.Errorf("NewEpisodeCount = %d, want 1", n)
+	}
+}
+
+// mapValues, durumları dilim olarak döner
+func mapValues(m map[string]Status) []Status {
+	out := make([]Status, 0, len(m))
+	for _, st := range m {
+		out = append(out, st)
+	}
+	return out
+}
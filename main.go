@@ -1,10 +1,9 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -13,208 +12,74 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/axrona/anitr-cli/internal"
 	"github.com/axrona/anitr-cli/internal/dl"
 	"github.com/axrona/anitr-cli/internal/flags"
+	"github.com/axrona/anitr-cli/internal/httpx"
 	"github.com/axrona/anitr-cli/internal/models"
 	"github.com/axrona/anitr-cli/internal/player"
 	"github.com/axrona/anitr-cli/internal/rpc"
-	"github.com/axrona/anitr-cli/internal/sources/animecix"
-	"github.com/axrona/anitr-cli/internal/sources/openanime"
+	"github.com/axrona/anitr-cli/internal/sources"
+	_ "github.com/axrona/anitr-cli/internal/sources/animecix"
+	_ "github.com/axrona/anitr-cli/internal/sources/openanime"
 	"github.com/axrona/anitr-cli/internal/ui"
 	"github.com/axrona/anitr-cli/internal/ui/tui"
 	"github.com/axrona/anitr-cli/internal/update"
 	"github.com/axrona/anitr-cli/internal/utils"
+	"github.com/axrona/anitr-cli/internal/watchlist"
 	"github.com/spf13/cobra"
 )
 
-// updateWatchAPI, seçilen kaynağa (animecix veya openanime) göre bir bölümün izlenebilir URL'lerini ve altyazı bilgilerini getirir.
-// Ayrıca varsa TR altyazı URL'sini de döner.
-// Params:
-// - source: kaynak adı ("animecix", "openanime")
-// - episodeData: bölüm listesi
-// - index: seçilen bölümün dizindeki yeri
-// - id: anime ID'si
-// - seasonIndex: sezonun sıfırdan başlayan indeksi
-// - selectedFansubIndex: openanime için seçilen fansub'un sırası
-// - isMovie: film mi dizi mi
-// - slug: openanime için gerekli olan tanımlayıcı
+// updateWatchAPI, seçilen kaynağa göre bir bölümün izlenebilir URL'lerini ve altyazı bilgilerini getirir.
+// Ayrıca varsa TR altyazı URL'sini de döner. Çözümleme sources.ResolveStreams üzerinden yapılır.
 //
 // Returns:
 // - İzlenebilir kaynakları ve altyazı URL'sini içeren map[string]interface{}
-// - Eğer openanime seçildiyse, fansub'ları içeren []models.Fansub
+// - Kaynak fansub destekliyorsa, fansub'ları içeren []models.Fansub
 // - Hata (varsa)
 func updateWatchAPI(
-	source string,
+	ctx context.Context,
+	source models.AnimeSource,
 	episodeData []models.Episode,
 	index, id, seasonIndex, selectedFansubIndex int,
 	isMovie bool,
 	slug *string,
 ) (map[string]interface{}, []models.Fansub, error) {
-	var (
-		captionData []map[string]string // Video etiketleri ve URL'leri
-		fansubData  []models.Fansub     // Fansub listesi (openanime için)
-		captionURL  string              // Türkçe altyazı URL'si
-		err         error
-	)
-
-	switch source {
-	case "animecix":
-		// Film ise farklı API kullan
-		if isMovie {
-			data, err := animecix.AnimeMovieWatchApiUrl(id)
-			if err != nil {
-				return nil, nil, fmt.Errorf("animecix movie API çağrısı başarısız: %w", err)
-			}
-			// Caption URL ve video stream'leri al
-			captionURLIface := data["caption_url"]
-			captionURL, _ = captionURLIface.(string)
-			streamsIface, ok := data["video_streams"]
-			if !ok {
-				return nil, nil, fmt.Errorf("video_streams beklenen formatta değil")
-			}
-			rawStreams, _ := streamsIface.([]interface{})
-			for _, streamIface := range rawStreams {
-				stream, _ := streamIface.(map[string]interface{})
-				label := internal.GetString(stream, "label")
-				url := internal.GetString(stream, "url")
-				captionData = append(captionData, map[string]string{"label": label, "url": url})
-			}
-		} else {
-			// Dizi bölümü için
-			if index < 0 || index >= len(episodeData) {
-				return nil, nil, fmt.Errorf("index out of range")
-			}
-			urlData := episodeData[index].ID
-			captionData, err = animecix.AnimeWatchApiUrl(urlData)
-			if err != nil {
-				return nil, nil, fmt.Errorf("animecix watch API çağrısı başarısız: %w", err)
-			}
-			// Sezon içerisindeki bölüm indeksini bul
-			seasonEpisodeIndex := 0
-			for i := 0; i < index; i++ {
-				if sn, ok := episodeData[i].Extra["season_num"].(int); ok {
-					if sn-1 == seasonIndex {
-						seasonEpisodeIndex++
-					}
-				} else if snf, ok := episodeData[i].Extra["season_num"].(float64); ok {
-					if int(snf)-1 == seasonIndex {
-						seasonEpisodeIndex++
-					}
-				}
-			}
-			// TR altyazı URL'sini almaya çalış
-			captionURL, err = animecix.FetchTRCaption(seasonIndex, seasonEpisodeIndex, id)
-			if err != nil {
-				captionURL = ""
-			}
-		}
-
-	case "openanime":
-		if slug == nil {
-			return nil, nil, fmt.Errorf("slug gerekli")
-		}
-		if index < 0 || index >= len(episodeData) {
-			return nil, nil, fmt.Errorf("index out of range")
-		}
-		ep := episodeData[index]
-		seasonNum := 0
-		episodeNum := 0
-
-		// Sezon ve bölüm numaralarını al
-		if sn, ok := ep.Extra["season_num"].(int); ok {
-			seasonNum = sn
-		} else if snf, ok := ep.Extra["season_num"].(float64); ok {
-			seasonNum = int(snf)
-		} else {
-			return nil, nil, fmt.Errorf("season_num beklenen formatta değil")
-		}
-		if en, ok := ep.Extra["episode_num"].(int); ok {
-			episodeNum = en
-		} else if enf, ok := ep.Extra["episode_num"].(float64); ok {
-			episodeNum = int(enf)
-		} else {
-			episodeNum = ep.Number
-		}
-
-		// Fansub listesini al
-		fansubParams := models.FansubParams{
-			Slug:       slug,
-			SeasonNum:  &seasonNum,
-			EpisodeNum: &episodeNum,
-		}
-		fansubData, err = openanime.OpenAnime{}.GetFansubsData(fansubParams)
-		if err != nil {
-			return nil, nil, fmt.Errorf("fansub data API çağrısı başarısız: %w", err)
-		}
-		if selectedFansubIndex < 0 || selectedFansubIndex >= len(fansubData) {
-			return nil, nil, fmt.Errorf("seçilen fansub indeksi geçersiz")
-		}
-
-		// İzlenebilir veri isteği yap
-		watchParams := models.WatchParams{
-			Slug:    slug,
-			Id:      &id,
-			IsMovie: &isMovie,
-			Extra: &map[string]interface{}{
-				"season_num":         seasonNum,
-				"episode_num":        episodeNum,
-				"fansubs":            fansubData,
-				"selected_fansub_id": selectedFansubIndex,
-			},
-		}
-		watches, err := openanime.OpenAnime{}.GetWatchData(watchParams)
-		if err != nil {
-			return nil, nil, fmt.Errorf("openanime watch data alınamadı: %w", err)
-		}
-		if len(watches) < 1 {
-			return nil, nil, fmt.Errorf("openanime watch data boş")
-		}
-		w := watches[0]
-		captionData = make([]map[string]string, len(w.Labels))
-		for i := range w.Labels {
-			captionData[i] = map[string]string{
-				"label": w.Labels[i],
-				"url":   w.Urls[i],
-			}
-		}
-		if w.TRCaption != nil {
-			captionURL = *w.TRCaption
-		}
-
-	default:
-		return nil, nil, fmt.Errorf("geçersiz kaynak: %s", source)
-	}
-
-	// Kaliteye göre (etiket sayısal değerine göre) sırala
-	sort.Slice(captionData, func(i, j int) bool {
-		labelI := strings.TrimRight(captionData[i]["label"], "p")
-		labelJ := strings.TrimRight(captionData[j]["label"], "p")
-		intI, _ := strconv.Atoi(labelI)
-		intJ, _ := strconv.Atoi(labelJ)
-		return intI > intJ
+	streams, fansubData, err := sources.ResolveStreams(ctx, source, sources.WatchRequest{
+		Episodes:    episodeData,
+		Index:       index,
+		ID:          id,
+		Slug:        slug,
+		SeasonIndex: seasonIndex,
+		FansubIndex: selectedFansubIndex,
+		IsMovie:     isMovie,
 	})
-
-	// Etiketleri ve URL'leri ayır
-	labels := []string{}
-	urls := []string{}
-	for _, item := range captionData {
-		labels = append(labels, item["label"])
-		urls = append(urls, item["url"])
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return map[string]interface{}{
-		"labels":      labels,
-		"urls":        urls,
-		"caption_url": captionURL,
+		"labels":      streams.Labels,
+		"urls":        streams.URLs,
+		"caption_url": streams.CaptionURL,
 	}, fansubData, nil
 }
 
+// fansubName, seçilen fansub'ın adını döner. Kaynak fansub desteklemiyorsa boş döner.
+func fansubName(fansubs []models.Fansub, idx int) string {
+	if idx < 0 || idx >= len(fansubs) || fansubs[idx].Name == nil {
+		return ""
+	}
+	return *fansubs[idx].Name
+}
+
 // getSelectedEpisodesLinks, seçilen bölümlerin sadece seçilmiş çözünürlük URL'lerini döner
 func getSelectedEpidodesLinks(
-	source string,
+	ctx context.Context,
+	source models.AnimeSource,
 	episodes []models.Episode,
 	selectedFansubIndex int,
 	isMovie bool,
@@ -228,6 +93,7 @@ func getSelectedEpidodesLinks(
 	for _, ep := range episodes {
 		// updateWatchAPI ile tek bölüm için veriyi al
 		data, _, err := updateWatchAPI(
+			ctx,
 			source,
 			[]models.Episode{ep}, // tek bölüm
 			0,                    // index 0 çünkü slice sadece 1 eleman
@@ -267,6 +133,188 @@ func getSelectedEpidodesLinks(
 	return result, nil
 }
 
+// editAnimeSkips, animenin intro ve outro aralıklarını menüden düzenletir.
+// Aralıklar "dd:ss-dd:ss" biçiminde girilir; oynatma sırasında Alt+I / Alt+O ile de işaretlenebilir.
+func editAnimeSkips(uiMode, rofiFlags, source, animeId, animeName string) error {
+	skips := utils.GetAnimeSkips(source, animeId)
+	describe := func(r *utils.SkipRange) string {
+		if r == nil {
+			return "yok"
+		}
+		return fmt.Sprintf("%s-%s", formatDuration(r.Start), formatDuration(r.End))
+	}
+
+	options := []string{"Intro ayarla", "Outro ayarla"}
+	if skips.Intro != nil {
+		options = append(options, "Intro sil")
+	}
+	if skips.Outro != nil {
+		options = append(options, "Outro sil")
+	}
+
+	choice, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, options,
+		fmt.Sprintf("Intro: %s | Outro: %s", describe(skips.Intro), describe(skips.Outro)))
+	if err != nil {
+		return err
+	}
+
+	kind := utils.SkipIntro
+	if strings.HasPrefix(choice, "Outro") {
+		kind = utils.SkipOutro
+	}
+
+	switch choice {
+	case "Intro sil", "Outro sil":
+		return utils.SetAnimeSkip(source, animeId, animeName, kind, nil)
+	case "Intro ayarla", "Outro ayarla":
+		input, err := ui.InputFromUser(internal.UiParams{
+			Mode:      uiMode,
+			RofiFlags: &rofiFlags,
+			Label:     fmt.Sprintf("%s aralığı (örnek: 01:30-03:00) ", strings.TrimSuffix(choice, " ayarla")),
+		})
+		if err != nil {
+			return err
+		}
+		r, err := utils.ParseSkipRange(input)
+		if err != nil {
+			return err
+		}
+		return utils.SetAnimeSkip(source, animeId, animeName, kind, r)
+	}
+	return nil
+}
+
+// progressBarWidth, bölüm seçicideki ilerleme çubuğunun hücre sayısıdır
+const progressBarWidth = 10
+
+// episodeBadges, bölüm seçicide bölüm adlarının yanında gösterilecek izlenme durumlarını hazırlar.
+// İzlenen bölümler ✓, yarıda bırakılanlar ilerleme çubuğu ile işaretlenir.
+func episodeBadges(source, animeId string, episodeNames []string) map[string]string {
+	badges := make(map[string]string)
+	for idx, record := range utils.EpisodeRecords(source, animeId) {
+		if idx < 0 || idx >= len(episodeNames) {
+			continue
+		}
+		if record.Watched {
+			badges[episodeNames[idx]] = "✓"
+			continue
+		}
+		if progress := record.Progress(); progress > 0 {
+			filled := int(progress*progressBarWidth + 0.5)
+			badges[episodeNames[idx]] = fmt.Sprintf("%s%s %d%%",
+				strings.Repeat("▰", filled), strings.Repeat("▱", progressBarWidth-filled), int(progress*100))
+		}
+	}
+	return badges
+}
+
+// selectEpisode, bölüm listesini izlenme durumlarıyla birlikte gösterir ve seçilen bölümün indeksini döner
+func selectEpisode(uiMode, rofiFlags, source, animeId string, episodeNames []string, label string) (int, error) {
+	selected, err := ui.SelectionList(internal.UiParams{
+		Mode:      uiMode,
+		RofiFlags: &rofiFlags,
+		List:      &episodeNames,
+		Label:     label,
+		Badges:    episodeBadges(source, animeId, episodeNames),
+	})
+	if err != nil {
+		return -1, err
+	}
+	return slices.Index(episodeNames, selected), nil
+}
+
+// markEpisodes, seçilen bölümleri izlendi ya da izlenmedi olarak işaretler
+func markEpisodes(uiMode, rofiFlags, source, animeId, animeName string, episodeNames []string, watched bool) error {
+	label := "İzlendi olarak işaretlenecek bölümleri seç "
+	if !watched {
+		label = "İzlenmedi olarak işaretlenecek bölümleri seç "
+	}
+
+	selected, err := ui.MultiSelectList(internal.UiParams{
+		Mode:      uiMode,
+		RofiFlags: &rofiFlags,
+		List:      &episodeNames,
+		Label:     label,
+		Badges:    episodeBadges(source, animeId, episodeNames),
+	})
+	if err != nil {
+		return err
+	}
+
+	indices := make([]int, 0, len(selected))
+	for _, name := range selected {
+		if idx := slices.Index(episodeNames, name); idx >= 0 {
+			indices = append(indices, idx)
+		}
+	}
+	return utils.MarkEpisodes(source, animeId, animeName, episodeNames, indices, watched)
+}
+
+// historyAnimeID, geçmişte animeyi tanımlamak için kullanılan ID'yi döner.
+// Slug kullanan kaynaklarda slug, diğerlerinde sayısal ID kullanılır.
+func historyAnimeID(source models.AnimeSource, id int, slug string) string {
+	if info, _ := sources.Lookup(source); info.Capabilities.Slug {
+		return slug
+	}
+	return strconv.Itoa(id)
+}
+
+// resolvePlaylist, from ve to (dahil) arasındaki bölümlerin akışlarını aynı fansub,
+// çözünürlük ve altyazı diliyle çözer. Bölümler sources.FetchAll ile paralel alınır.
+func resolvePlaylist(
+	ctx context.Context,
+	source models.AnimeSource,
+	episodes []models.Episode,
+	from, to, id int,
+	slug *string,
+	fansubIdx int,
+	resolution string,
+	subtitleLang string,
+) ([]playlistEntry, error) {
+	return sources.FetchAll(ctx, to-from+1, func(ctx context.Context, i int) (playlistEntry, error) {
+		index := from + i
+		seasonIndex := sources.SeasonIndexOf(episodes[index])
+
+		data, fansubs, err := updateWatchAPI(ctx, source, episodes, index, id, seasonIndex, fansubIdx, false, slug)
+		if err != nil {
+			return playlistEntry{}, fmt.Errorf("[%s] %w", episodes[index].Title, err)
+		}
+
+		labels := data["labels"].([]string)
+		urls := data["urls"].([]string)
+		if len(urls) == 0 {
+			return playlistEntry{}, fmt.Errorf("[%s] video bulunamadı", episodes[index].Title)
+		}
+
+		// Seçilen çözünürlük yoksa en yüksek çözünürlük kullanılır
+		resolutionIdx := slices.Index(labels, resolution)
+		if resolutionIdx < 0 || resolutionIdx >= len(urls) {
+			resolutionIdx = 0
+		}
+
+		// Kullanıcı farklı bir altyazı dili seçtiyse onu kullan
+		subtitle := data["caption_url"].(string)
+		if subtitleLang != "" {
+			subtitles, err := fetchSubtitles(ctx, source, episodes, index, id, seasonIndex, false, slug)
+			if err == nil {
+				for _, sub := range subtitles {
+					if sub.Language == subtitleLang {
+						subtitle = sub.Url
+						break
+					}
+				}
+			}
+		}
+
+		return playlistEntry{
+			episodeIndex: index,
+			url:          urls[resolutionIdx],
+			subtitle:     subtitle,
+			fansub:       fansubName(fansubs, fansubIdx),
+		}, nil
+	})
+}
+
 // --- UI ve kullanıcı etkileşimi fonksiyonları ---
 
 // Ana menü
@@ -276,13 +324,19 @@ func mainMenu(cfx *App, timestamp time.Time) {
 		ui.ClearScreen()
 
 		// Menü seçenekleri
-		menuOptions := []string{"Anime Ara", "Kaynak Değiştir", "Geçmiş", "Ayarlar", "Çık"}
+		menuOptions := []string{"Anime Ara", "Kaynak Değiştir", "Geçmiş", "İzleme listesi", "Ayarlar", "Çık"}
 
 		// Kullanıcıya mevcut kaynağı göster
 		label := fmt.Sprintf("Kaynak: %s", *cfx.selectedSource)
 
-		// Seçim al
-		selectedChoice, err := showSelection(*cfx, menuOptions, label)
+		// Seçim al; izleme listesinde yeni bölüm varsa rozetle gösterilir
+		selectedChoice, err := ui.SelectionList(internal.UiParams{
+			Mode:      *cfx.uiMode,
+			RofiFlags: cfx.rofiFlags,
+			List:      &menuOptions,
+			Label:     label,
+			Badges:    mainMenuBadges(cfx),
+		})
 		if err != nil {
 			cfx.logger.LogError(err)
 			continue
@@ -304,10 +358,15 @@ func mainMenu(cfx *App, timestamp time.Time) {
 			cfx.source = utils.Ptr(source)
 
 		case "Geçmiş":
-			historySelectedAnime, historyAnimeId, _, err := anitrHistory(internal.UiParams{
+			// Ayarlarda açıksa tüm kaynakların geçmişi birlikte gösterilir
+			historySource := sources.ID(*cfx.source)
+			if cfx.historyAllSources {
+				historySource = ""
+			}
+			historySelectedAnime, historyAnimeId, historySourceID, _, err := anitrHistory(internal.UiParams{
 				Mode:      *cfx.uiMode,
 				RofiFlags: cfx.rofiFlags,
-			}, strings.ToLower(*cfx.selectedSource), cfx.historyLimit, cfx.logger)
+			}, historySource, cfx.historyLimit, cfx.logger)
 
 			if errors.Is(err, tui.ErrGoBack) {
 				continue
@@ -317,19 +376,30 @@ func mainMenu(cfx *App, timestamp time.Time) {
 				break
 			}
 
+			// Seçilen kayıt başka bir kaynaktansa --go'daki gibi o kaynağa geçilir
+			if info, ok := sources.Get(historySourceID); ok && historySourceID != sources.ID(*cfx.source) {
+				source := info.Source
+				cfx.source = &source
+				cfx.selectedSource = utils.Ptr(info.Name)
+			}
+
+			// Geçmiş menüde değişmiş olabilir; devam edilecek bölüm güncel kayıttan seçilir
+			if history, err := utils.ReadAnimeHistory(); err == nil {
+				cfx.animeHistory = &history
+			}
+
 			// Loading spinner başlat
-			done := make(chan struct{})
-			go ui.ShowLoading(internal.UiParams{
+			ctx, stop := ui.Loading(context.Background(), internal.UiParams{
 				Mode:      *cfx.uiMode,
 				RofiFlags: cfx.rofiFlags,
-			}, "Yükleniyor...", done)
+			}, "Yükleniyor...")
 
 			var (
 				animeSlug string
 				animeId   int
 			)
 
-			if strings.ToLower(*cfx.selectedSource) == "openanime" {
+			if info, _ := sources.Lookup(*cfx.source); info.Capabilities.Slug {
 				animeSlug = historyAnimeId
 			} else {
 				animeId, err = strconv.Atoi(historyAnimeId)
@@ -337,10 +407,15 @@ func mainMenu(cfx *App, timestamp time.Time) {
 
 			// Bölümleri al
 			episodes, episodeNames, isMovie, selectedSeasonIndex, err := getEpisodesAndNames(
-				*cfx.source, false, animeId, animeSlug, historySelectedAnime,
+				ctx, *cfx.source, false, animeId, animeSlug, historySelectedAnime,
 			)
 			if err != nil {
-				close(done) // spinneri durdur
+				stop() // spinneri durdur
+
+				// Kullanıcı yüklemeyi iptal ettiyse menüye dön
+				if errors.Is(err, context.Canceled) {
+					continue
+				}
 
 				cfx.logger.LogError(err)
 
@@ -369,28 +444,31 @@ func mainMenu(cfx *App, timestamp time.Time) {
 
 			// Animenin verilerini çek
 			source := *cfx.source
-			selectedAnime, err := source.GetAnimeByID(historyAnimeId)
+			selectedAnime, err := source.GetAnimeByID(ctx, historyAnimeId)
 			if err != nil {
-				close(done) // spinneri durdur
+				stop() // spinneri durdur
+				if errors.Is(err, context.Canceled) {
+					continue
+				}
 				cfx.logger.LogError(err)
 				return
 			}
 
 			// Poster URL al
 			posterURL := selectedAnime.ImageURL
-			if !utils.IsValidImage(posterURL) {
+			if !utils.IsValidImage(ctx, posterURL) {
 				posterURL = "anitrcli"
 			}
 
 			// Loading spinner durdur
-			close(done)
+			stop()
 
 			// Oynatma döngüsü
 			newSource, newSelectedSource, err := playAnimeLoop(
 				*cfx.source, *cfx.selectedSource, episodes, episodeNames,
 				animeId, animeSlug, historySelectedAnime,
 				isMovie, selectedSeasonIndex, *cfx.uiMode, *cfx.rofiFlags,
-				posterURL, *cfx.disableRPC, timestamp, *cfx.animeHistory, cfx.logger,
+				posterURL, *cfx.disableRPC, cfx.playback, timestamp, *cfx.animeHistory, cfx.logger,
 			)
 			if err != nil {
 				cfx.logger.LogError(err)
@@ -402,6 +480,13 @@ func mainMenu(cfx *App, timestamp time.Time) {
 				cfx.selectedSource = &newSelectedSource
 			}
 
+		case "İzleme listesi":
+			if err := watchlistMenu(cfx, timestamp); err != nil && !errors.Is(err, tui.ErrGoBack) {
+				cfx.logger.LogError(err)
+				fmt.Printf("\033[31m[!] %s\033[0m\n", err)
+				time.Sleep(1500 * time.Millisecond)
+			}
+
 		case "Ayarlar":
 			settingsMenu(cfx)
 
@@ -412,29 +497,13 @@ func mainMenu(cfx *App, timestamp time.Time) {
 }
 
 func settingsMenu(cfx *App) {
-	cfg, err := utils.LoadConfig(filepath.Join(utils.ConfigDir(), "config.json"))
+	configPath := filepath.Join(utils.ConfigDir(), "config.json")
+	cfg, err := utils.LoadConfig(configPath)
 	if err != nil {
 		cfg = &utils.Config{}
 	}
 
-	// Dosyayı okuma ve yazma modunda açıyoruz, mevcut içeriği sıfırlayarak
-	// Config klasörü yoksa oluştur (özellikle Windows'ta ilk açılışta yok olabilir)
-	if err := os.MkdirAll(utils.ConfigDir(), 0o755); err != nil {
-		cfx.logger.LogError(fmt.Errorf("config klasörü oluşturulamadı: %w", err))
-		return
-	}
-	f, err := os.OpenFile(filepath.Join(utils.ConfigDir(), "config.json"), os.O_RDWR|os.O_CREATE, 0o644)
-	if err != nil {
-		cfx.logger.LogError(err)
-		return
-	}
-	defer f.Close() // Fonksiyon bitince dosya kapanır
-
-	// JSON yazıcı (Encoder değil)
-	encoder := json.NewEncoder(f)
-	encoder.SetIndent("", "  ")
-
-	// Menüdeki değişikliklerin kaydedilmesi için bir flag
+	// Menüde değişiklik yapılıp yapılmadığını tutan flag
 	var changesMade bool
 
 	for {
@@ -460,19 +529,14 @@ func settingsMenu(cfx *App) {
 			"Varsayılan kaynağı değiştir : " + selectedSourceText,
 			"Geçmiş limitini değiştir : " + fmt.Sprintf("%d", cfg.HistoryLimit),
 			"RPC'yi devre dışı bırak : " + disableRPCText,
+			"Geçmişte tüm kaynakları göster : " + fmt.Sprintf("%v", cfg.HistoryAllSources),
 			"Geri",
 		}
 
 		selectedChoice, err := showSelection(*cfx, menuOptions, "Ayarlar")
 		if errors.Is(err, tui.ErrGoBack) {
-			// Menüden çıkıldığında kaydetme işlemi yap
+			// Değişiklikler yapıldıkları anda kaydedildi
 			if changesMade {
-				// Dosyaya yazma işlemi sadece değişiklik yapıldıysa yapılacak
-				f.Seek(0, io.SeekStart) // Dosya pointer'ını başa al
-				f.Truncate(0)           // Dosyayı temizle
-				if err := encoder.Encode(cfg); err != nil {
-					cfx.logger.LogError(err)
-				}
 				fmt.Println("Ayarlar başarıyla güncellendi!")
 			} else {
 				// Değişiklik yapılmamışsa dosyayı yazma
@@ -485,7 +549,11 @@ func settingsMenu(cfx *App) {
 			continue
 		}
 
-		// Seçilen menü seçeneğine göre işlem yap
+		// Seçilen menü seçeneğine göre yapılacak değişiklik.
+		// Config dosyası kaydedilirken yeniden okunur ve yalnızca bu alan değiştirilir;
+		// böylece aynı anda açık başka bir oturumun yaptığı değişiklikler ezilmez.
+		var update func(*utils.Config)
+
 		switch selectedChoice {
 		case menuOptions[0]: // İndirme dizinini değiştir
 			homeDir := os.Getenv("HOME")
@@ -501,24 +569,21 @@ func settingsMenu(cfx *App) {
 				if strings.HasPrefix(input, "~") {
 					input = filepath.Join(homeDir, input[1:])
 				}
-				cfg.DownloadDir = input
-				changesMade = true // Flag'i true yapıyoruz, çünkü değişiklik yapıldı
+				update = func(c *utils.Config) { c.DownloadDir = input }
 			}
 
 		case menuOptions[1]: // Kaynak değiştir
 			selectedSourceName, selectedSource := selectSource(*cfx.uiMode, *cfx.rofiFlags, *cfx.source, cfx.logger)
 			cfx.selectedSource = &selectedSourceName
 			cfx.source = &selectedSource
-			cfg.DefaultSource = selectedSourceName
-			changesMade = true
+			update = func(c *utils.Config) { c.DefaultSource = sources.ID(selectedSource) }
 
 		case menuOptions[2]: // Geçmiş limitini değiştir
 			fmt.Print("Yeni geçmiş limitini girin: ")
 			var newLimit int
 			fmt.Scanln(&newLimit)
 			if newLimit >= 0 {
-				cfg.HistoryLimit = newLimit
-				changesMade = true
+				update = func(c *utils.Config) { c.HistoryLimit = newLimit }
 			}
 
 		case menuOptions[3]: // RPC'yi devre dışı bırak
@@ -532,42 +597,52 @@ func settingsMenu(cfx *App) {
 				return
 			}
 
-			switch strings.ToLower(choice) {
-			case "evet":
-				cfg.DisableRPC = utils.Ptr(true)
-			case "hayır":
-				cfg.DisableRPC = utils.Ptr(false)
-			default:
-				cfg.DisableRPC = utils.Ptr(false)
+			disable := strings.ToLower(choice) == "evet"
+			update = func(c *utils.Config) { c.DisableRPC = utils.Ptr(disable) }
+
+		case menuOptions[4]: // Geçmişte tüm kaynakları göster
+			choice, err := showSelection(
+				App{uiMode: cfx.uiMode, rofiFlags: cfx.rofiFlags},
+				[]string{"Evet", "Hayır"},
+				"Geçmiş menüsünde tüm kaynaklar birlikte gösterilsin mi?",
+			)
+			if errors.Is(err, tui.ErrGoBack) {
+				continue
 			}
-			changesMade = true
 
-		case menuOptions[4]: // Geri
+			all := strings.ToLower(choice) == "evet"
+			cfx.historyAllSources = all
+			update = func(c *utils.Config) { c.HistoryAllSources = all }
+
+		case menuOptions[5]: // Geri
 			return
 		}
 
 		// Değişiklikleri hemen kaydet
-		if changesMade {
-			// Dosyaya yazma işlemi manuel olarak yapılır
-			f.Seek(0, io.SeekStart) // Dosya pointer'ını başa al
-			f.Truncate(0)           // Dosyayı temizle
-			if err := encoder.Encode(cfg); err != nil {
+		if update != nil {
+			update(cfg)
+			if err := utils.UpdateConfig(configPath, update); err != nil {
 				cfx.logger.LogError(err)
+				fmt.Printf("\033[31m[!] Ayarlar kaydedilemedi: %s\033[0m\n", err)
+				time.Sleep(1500 * time.Millisecond)
+				continue
 			}
+			changesMade = true
 			fmt.Println("Ayarlar güncellendi!")
 		}
 	}
 }
 
-// Anime geçmişini listeleyen fonksiyon
-func anitrHistory(params internal.UiParams, source string, historyLimit int, logger *utils.Logger) (selectedAnime string, animeId string, lastEpisodeIdx int, err error) {
+// Anime geçmişini listeleyen fonksiyon.
+// source boşsa tüm kaynakların geçmişi birleştirilip son izlenme zamanına göre sıralanır
+// ve her kayıt kaynağının adıyla etiketlenir; seçilen kaydın kaynağı selectedSource ile döner.
+func anitrHistory(params internal.UiParams, source string, historyLimit int, logger *utils.Logger) (selectedAnime string, animeId string, selectedSource string, lastEpisodeIdx int, err error) {
 	// Loading spinner başlat
-	done := make(chan struct{})
-	go ui.ShowLoading(params, "Geçmiş yükleniyor...", done)
+	_, stop := ui.Loading(context.Background(), params, "Geçmiş yükleniyor...")
 
 	animeHistory, readErr := utils.ReadAnimeHistory()
 	if readErr != nil {
-		close(done)      // spinner'ı kapat
+		stop()           // spinner'ı kapat
 		ui.ClearScreen() // ekranı temizle
 		err = fmt.Errorf("Geçmiş bulunamadı")
 		fmt.Printf("\033[31m[!] %s\033[0m\n", err.Error())
@@ -576,9 +651,9 @@ func anitrHistory(params internal.UiParams, source string, historyLimit int, log
 		return
 	}
 
-	sourceData, ok := animeHistory[source]
-	if !ok || len(sourceData) == 0 {
-		close(done)      // spinner'ı kapat
+	allSources := source == ""
+	if sourceData, ok := animeHistory[source]; !allSources && (!ok || len(sourceData) == 0) {
+		stop()           // spinner'ı kapat
 		ui.ClearScreen() // ekranı temizle
 		err = fmt.Errorf("Bu kaynak için geçmiş bulunamadı")
 		fmt.Printf("\033[31m[!] %s\033[0m\n", err.Error())
@@ -591,23 +666,48 @@ func anitrHistory(params internal.UiParams, source string, historyLimit int, log
 		Key       string
 		AnimeName string
 		AnimeId   string
+		Source    string
 		Idx       int
 		Time      time.Time
 	}
 
 	var items []item
-	for animeName, entry := range sourceData {
-		if entry.LastEpisodeName == "" || entry.LastEpisodeIdx == nil || entry.AnimeId == nil || entry.LastWatched == nil || entry.LastWatched.IsZero() {
+	for sourceID, sourceData := range animeHistory {
+		if !allSources && sourceID != source {
 			continue
 		}
-		key := fmt.Sprintf("%s %s", animeName, entry.LastEpisodeName)
-		items = append(items, item{
-			Key:       key,
-			AnimeName: animeName,
-			AnimeId:   *entry.AnimeId,
-			Idx:       *entry.LastEpisodeIdx,
-			Time:      *entry.LastWatched,
-		})
+		// Birleşik görünümde kayıtlar kaynağın adıyla etiketlenir
+		tag := ""
+		if allSources {
+			info, ok := sources.Get(sourceID)
+			if !ok {
+				continue // Artık kayıtlı olmayan kaynak
+			}
+			tag = fmt.Sprintf("[%s] ", info.Name)
+		}
+
+		for animeId, entry := range sourceData {
+			if entry.LastEpisodeName == "" || entry.LastEpisodeIdx == nil || entry.LastWatched == nil || entry.LastWatched.IsZero() {
+				continue
+			}
+			key := fmt.Sprintf("%s%s %s", tag, entry.Title, entry.LastEpisodeName)
+			// Yarıda bırakılan bölümde izlenen oranı göster
+			if record, ok := entry.Episodes[strconv.Itoa(*entry.LastEpisodeIdx)]; ok && !record.Watched {
+				if progress := record.Progress(); progress > 0 {
+					key = fmt.Sprintf("%s (%%%d)", key, int(progress*100))
+				} else {
+					key += " (başlandı)"
+				}
+			}
+			items = append(items, item{
+				Key:       key,
+				AnimeName: entry.Title,
+				AnimeId:   animeId,
+				Source:    sourceID,
+				Idx:       *entry.LastEpisodeIdx,
+				Time:      *entry.LastWatched,
+			})
+		}
 	}
 
 	// en yeniden en eskiye sırala
@@ -616,19 +716,18 @@ func anitrHistory(params internal.UiParams, source string, historyLimit int, log
 	})
 
 	// historyLimit ile sınırla
-	if historyLimit > len(items) {
-		historyLimit = len(items)
-	}
-
-	if historyLimit > 0 {
+	if historyLimit > 0 && historyLimit < len(items) {
 		items = items[:historyLimit]
 	}
 
-	close(done) // spinner durdur
+	stop() // spinner durdur
 	ui.ClearScreen()
 
 	if len(items) == 0 {
 		err = fmt.Errorf("Bu kaynak için geçmiş bulunamadı")
+		if allSources {
+			err = fmt.Errorf("Geçmiş bulunamadı")
+		}
 		fmt.Printf("\033[31m[!] %s\033[0m\n", err.Error())
 		time.Sleep(1500 * time.Millisecond)
 		return
@@ -654,28 +753,252 @@ func anitrHistory(params internal.UiParams, source string, historyLimit int, log
 	}
 
 	// seçilen animeyi bul
-	found := false
-	for _, it := range items {
-		if it.Key == selectedKey {
-			selectedAnime = it.AnimeName
-			animeId = it.AnimeId
-			lastEpisodeIdx = it.Idx
-			found = true
+	var selected *item
+	for i := range items {
+		if items[i].Key == selectedKey {
+			selected = &items[i]
 			break
 		}
 	}
-	if !found {
+	if selected == nil {
 		err = fmt.Errorf("Seçilen anime bulunamadı: %s", selectedKey)
+		return
+	}
+
+	// Seçilen kayıt için yapılacak işlemi sor
+	action, selErr := ui.SelectionList(internal.UiParams{
+		Mode:      params.Mode,
+		List:      &historyActions,
+		Label:     selected.AnimeName,
+		RofiFlags: params.RofiFlags,
+	})
+	if errors.Is(selErr, tui.ErrGoBack) {
+		return anitrHistory(params, source, historyLimit, logger)
+	}
+	if selErr != nil {
+		err = selErr
+		return
+	}
+
+	switch action {
+	case "Geçmişten sil":
+		if err = utils.RemoveAnimeHistory(selected.Source, selected.AnimeId); err != nil {
+			return
+		}
+		return anitrHistory(params, source, historyLimit, logger)
+
+	case "Baştan başla":
+		if err = utils.SetLastEpisode(selected.Source, selected.AnimeId, 0, ""); err != nil {
+			return
+		}
+		selected.Idx = 0
+	}
+
+	return selected.AnimeName, selected.AnimeId, selected.Source, selected.Idx, nil
+}
+
+// historyActions, Geçmiş'te seçilen anime için sunulan işlemlerdir
+var historyActions = []string{"İzlemeye devam et", "Baştan başla", "Geçmişten sil"}
+
+// watchlistState, izleme listesinin son denetim sonucunu tutar.
+// Denetim arka planda yapılır; ana menü rozeti sonuç geldiğinde güncellenir.
+type watchlistState struct {
+	mu       sync.Mutex
+	statuses []watchlist.Status
+}
+
+// set, son denetim sonucunu kaydeder
+func (w *watchlistState) set(statuses []watchlist.Status) {
+	w.mu.Lock()
+	w.statuses = statuses
+	w.mu.Unlock()
+}
+
+// get, son denetim sonucunu döner; henüz denetim yapılmadıysa nil döner
+func (w *watchlistState) get() []watchlist.Status {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.statuses
+}
+
+// checkWatchlist, izleme listesindeki animelerin yeni bölümlerini denetleyip sonucu kaydeder
+func checkWatchlist(ctx context.Context, cfx *App) ([]watchlist.Status, error) {
+	list, err := utils.ReadWatchlist()
+	if err != nil {
+		return nil, err
+	}
+	history, err := utils.ReadAnimeHistory()
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := watchlist.Check(ctx, list, history)
+	if err != nil {
+		return nil, err
+	}
+	cfx.watchlist.set(statuses)
+	return statuses, nil
+}
+
+// mainMenuBadges, ana menüde izleme listesinin yanında yeni bölümü olan anime sayısını gösterir
+func mainMenuBadges(cfx *App) map[string]string {
+	if n := watchlist.NewEpisodeCount(cfx.watchlist.get()); n > 0 {
+		return map[string]string{"İzleme listesi": fmt.Sprintf("● %d yeni", n)}
+	}
+	return nil
+}
+
+// watchlistActions, İzleme listesinde seçilen anime için sunulan işlemlerdir
+var watchlistActions = []string{"İzle", "Listeden çıkar"}
+
+// watchlistMenu, izleme listesini yeni bölüm bilgisiyle gösterir ve seçilen animeyi açar
+func watchlistMenu(cfx *App, timestamp time.Time) error {
+	params := internal.UiParams{Mode: *cfx.uiMode, RofiFlags: cfx.rofiFlags}
+
+	for {
+		// Liste açılırken bölümler yeniden denetlenir
+		ctx, stop := ui.Loading(context.Background(), params, "İzleme listesi denetleniyor...")
+		statuses, err := checkWatchlist(ctx, cfx)
+		stop()
+		if errors.Is(err, context.Canceled) {
+			return tui.ErrGoBack
+		}
+		if err != nil {
+			return err
+		}
+
+		ui.ClearScreen()
+		if len(statuses) == 0 {
+			fmt.Println("\033[31m[!] İzleme listesi boş. İzleme menüsünden ya da arama sonuçlarından anime ekleyebilirsiniz.\033[0m")
+			time.Sleep(2 * time.Second)
+			return nil
+		}
+
+		keys := make([]string, 0, len(statuses))
+		badges := make(map[string]string)
+		for _, st := range statuses {
+			key := st.Title
+			if info, ok := sources.Get(st.Source); ok {
+				key = fmt.Sprintf("[%s] %s", info.Name, st.Title)
+			}
+			keys = append(keys, key)
+
+			switch {
+			case st.Err != nil:
+				badges[key] = "denetlenemedi"
+			case st.NewEpisodes > 0:
+				badges[key] = fmt.Sprintf("● %d yeni bölüm", st.NewEpisodes)
+			case st.LastWatched > 0:
+				badges[key] = fmt.Sprintf("%d/%d", st.LastWatched, st.Episodes)
+			}
+		}
+
+		selectedKey, err := ui.SelectionList(internal.UiParams{
+			Mode:      *cfx.uiMode,
+			List:      &keys,
+			Label:     "İzleme listesi",
+			RofiFlags: cfx.rofiFlags,
+			Badges:    badges,
+		})
+		if err != nil {
+			return err
+		}
+		idx := slices.Index(keys, selectedKey)
+		if idx < 0 {
+			continue
+		}
+		selected := statuses[idx]
+
+		action, err := ui.SelectionList(internal.UiParams{
+			Mode:      *cfx.uiMode,
+			List:      &watchlistActions,
+			Label:     selected.Title,
+			RofiFlags: cfx.rofiFlags,
+		})
+		if errors.Is(err, tui.ErrGoBack) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		switch action {
+		case "Listeden çıkar":
+			if err := utils.RemoveFromWatchlist(selected.Source, selected.AnimeID); err != nil {
+				return err
+			}
+
+		case "İzle":
+			// İzleme menüsünden geri dönülürse liste yeniden denetlenip gösterilir
+			err := openSavedAnime(cfx, timestamp, selected.Source, selected.AnimeID)
+			if errors.Is(err, tui.ErrGoBack) {
+				continue
+			}
+			return err
+		}
+	}
+}
+
+// watchlistAddOption, arama sonuçlarından izleme listesine eklemek için listenin başına konan seçenektir
+const watchlistAddOption = "★ İzleme listesine ekle..."
+
+// searchResultBadges, izleme listesindeki arama sonuçlarını ★ ile işaretler
+func searchResultBadges(source models.AnimeSource, animeNames []string, searchData []models.Anime) map[string]string {
+	list, err := utils.ReadWatchlist()
+	if err != nil {
+		return nil
+	}
+	sourceList := list[sources.ID(source)]
+
+	badges := make(map[string]string)
+	for i, anime := range searchData {
+		if i >= len(animeNames) {
+			break
+		}
+		id, slug := getAnimeIDs(source, anime)
+		if _, ok := sourceList[historyAnimeID(source, id, slug)]; ok {
+			badges[animeNames[i]] = "★"
+		}
+	}
+	return badges
+}
+
+// bookmarkAnimes, arama sonuçlarından seçilenleri izleme listesine ekler
+func bookmarkAnimes(uiMode, rofiFlags string, source models.AnimeSource, animeNames []string, searchData []models.Anime, animeTypes []string) error {
+	selected, err := ui.MultiSelectList(internal.UiParams{
+		Mode:      uiMode,
+		RofiFlags: &rofiFlags,
+		List:      &animeNames,
+		Label:     "İzleme listesine eklenecek animeleri seç ",
+		Badges:    searchResultBadges(source, animeNames, searchData),
+	})
+	if err != nil {
+		return err
 	}
 
-	return
+	for _, name := range selected {
+		idx := slices.Index(animeNames, name)
+		if idx < 0 || idx >= len(searchData) {
+			continue
+		}
+		anime := searchData[idx]
+		id, slug := getAnimeIDs(source, anime)
+		entry := utils.WatchlistEntry{
+			Title:   anime.Title,
+			Poster:  anime.ImageURL,
+			IsMovie: idx < len(animeTypes) && animeTypes[idx] == "movie",
+		}
+		if err := utils.AddToWatchlist(sources.ID(source), historyAnimeID(source, id, slug), entry); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Kullanıcıdan kaynak seçmesini isteyen fonksiyon
 func selectSource(uiMode, rofiFlags string, defaultSource models.AnimeSource, logger *utils.Logger) (string, models.AnimeSource) {
 	for {
 		// Kaynak listesi
-		sourceList := []string{"OpenAnime", "AnimeciX"}
+		sourceList := sources.Names()
 
 		// Kullanıcıdan seçim al
 		selectedSource, err := showSelection(
@@ -695,20 +1018,14 @@ func selectSource(uiMode, rofiFlags string, defaultSource models.AnimeSource, lo
 			return "", nil
 		}
 
-		// Normalize et
-		src := strings.ToLower(strings.TrimSpace(selectedSource))
-
-		// Kaynağı eşleştir
-		switch src {
-		case "openanime":
-			return selectedSource, openanime.OpenAnime{}
-		case "animecix":
-			return selectedSource, animecix.AnimeCix{}
-		default:
+		// Kaynağı kayıt defterinden eşleştir
+		info, ok := sources.Get(selectedSource)
+		if !ok {
 			fmt.Printf("\033[31m[!] Geçersiz kaynak seçimi: %s\033[0m\n", selectedSource)
 			time.Sleep(1500 * time.Millisecond)
 			continue
 		}
+		return info.Name, info.Source
 	}
 }
 
@@ -729,18 +1046,22 @@ func searchAnime(source models.AnimeSource, uiMode string, rofiFlags string, log
 		}, err, logger)
 
 		// Loading spinner başlat
-		done := make(chan struct{})
-		go ui.ShowLoading(internal.UiParams{
+		ctx, stop := ui.Loading(context.Background(), internal.UiParams{
 			Mode:      uiMode,
 			RofiFlags: &rofiFlags,
-		}, "Aranıyor...", done)
+		}, "Aranıyor...")
 
 		// API üzerinden arama yap
-		searchData, err := source.GetSearchData(query)
+		searchData, err := source.GetSearchData(ctx, query)
 		if err != nil {
-			close(done)      // spinneri durdur
+			stop()           // spinneri durdur
 			ui.ClearScreen() // ekranı temizle
 
+			// Kullanıcı aramayı iptal ettiyse tekrar sor
+			if errors.Is(err, context.Canceled) {
+				continue
+			}
+
 			ui.ShowError(internal.UiParams{
 				Mode:      uiMode,
 				RofiFlags: &rofiFlags,
@@ -756,7 +1077,7 @@ func searchAnime(source models.AnimeSource, uiMode string, rofiFlags string, log
 		}
 		// Hiç sonuç çıkmazsa kullanıcıyı bilgilendir
 		if searchData == nil {
-			close(done)      // spinneri durdur
+			stop()           // spinneri durdur
 			ui.ClearScreen() // ekranı temizle
 			fmt.Printf("\033[31m[!] Arama sonucu bulunamadı!\033[0m")
 			time.Sleep(1500 * time.Millisecond)
@@ -784,19 +1105,27 @@ func searchAnime(source models.AnimeSource, uiMode string, rofiFlags string, log
 		}
 
 		// Loading spinneri durdur
-		close(done)
+		stop()
 
 		return searchData, animeNames, animeTypes, animeMap, nil
 	}
 }
 
 // Kullanıcının seçtiği animeyi belirler
-func selectAnime(animeNames []string, searchData []models.Anime, uiMode string, isMovie bool, rofiFlags string, animeTypes []string, logger *utils.Logger) (models.Anime, bool, int) {
+// Listenin başındaki seçenekle sonuçlar izleme listesine eklenebilir; listedekiler ★ ile işaretlenir.
+func selectAnime(source models.AnimeSource, animeNames []string, searchData []models.Anime, uiMode string, isMovie bool, rofiFlags string, animeTypes []string, logger *utils.Logger) (models.Anime, bool, int) {
 	for {
 		ui.ClearScreen()
 
 		// Kullanıcıdan anime seçimi al
-		selectedAnimeName, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, animeNames, "Anime seç ")
+		options := append([]string{watchlistAddOption}, animeNames...)
+		selectedAnimeName, err := ui.SelectionList(internal.UiParams{
+			Mode:      uiMode,
+			RofiFlags: &rofiFlags,
+			List:      &options,
+			Label:     "Anime seç ",
+			Badges:    searchResultBadges(source, animeNames, searchData),
+		})
 
 		if errors.Is(err, tui.ErrGoBack) {
 			// kullanıcı ESC bastı → fonksiyonu çağıran yere geri dön
@@ -808,6 +1137,14 @@ func selectAnime(animeNames []string, searchData []models.Anime, uiMode string,
 			RofiFlags: &rofiFlags,
 		}, err, logger)
 
+		if selectedAnimeName == watchlistAddOption {
+			if err := bookmarkAnimes(uiMode, rofiFlags, source, animeNames, searchData, animeTypes); err != nil && !errors.Is(err, tui.ErrGoBack) {
+				fmt.Printf("\033[31m[!] İzleme listesine eklenemedi: %s\033[0m\n", err)
+				time.Sleep(1500 * time.Millisecond)
+			}
+			continue
+		}
+
 		// Geçerli bir anime ismi mi kontrol et
 		if !slices.Contains(animeNames, selectedAnimeName) {
 			continue
@@ -833,64 +1170,283 @@ func getAnimeIDs(source models.AnimeSource, selectedAnime models.Anime) (int, st
 	var selectedAnimeSlug string
 
 	// Kaynağa göre ID veya slug alınır
-	if strings.ToLower(source.Source()) == "animecix" {
-		selectedID := selectedAnime.ID
-		selectedAnimeID = *selectedID
-	} else if strings.ToLower(source.Source()) == "openanime" {
-		selectedSlug := selectedAnime.Slug
-		selectedAnimeSlug = *selectedSlug
+	info, _ := sources.Lookup(source)
+	if info.Capabilities.Slug {
+		if selectedAnime.Slug != nil {
+			selectedAnimeSlug = *selectedAnime.Slug
+		}
+	} else if selectedAnime.ID != nil {
+		selectedAnimeID = *selectedAnime.ID
 	}
 	return selectedAnimeID, selectedAnimeSlug
 }
 
 // Seçilen animeye ait bölümleri getirir, isim listesi oluşturur ve movie olup olmadığını döner
-func getEpisodesAndNames(source models.AnimeSource, isMovie bool, selectedAnimeID int, selectedAnimeSlug string, selectedAnimeName string) ([]models.Episode, []string, bool, int, error) {
-	var (
-		episodes            []models.Episode
-		episodeNames        []string
-		selectedSeasonIndex int
-		err                 error
-	)
+func getEpisodesAndNames(ctx context.Context, source models.AnimeSource, isMovie bool, selectedAnimeID int, selectedAnimeSlug string, selectedAnimeName string) ([]models.Episode, []string, bool, int, error) {
+	episodes, isMovie, err := sources.LoadEpisodes(ctx, source, selectedAnimeID, selectedAnimeSlug, selectedAnimeName, isMovie)
+	if err != nil {
+		return nil, nil, false, 0, err
+	}
 
-	// OpenAnime ise sezon verisini alarak movie olup olmadığını kontrol et
-	if strings.ToLower(source.Source()) == "openanime" {
-		seasonData, err := source.GetSeasonsData(models.SeasonParams{Slug: &selectedAnimeSlug})
-		if err != nil {
-			return nil, nil, false, 0, fmt.Errorf("sezon verisi alınamadı: %w", err)
+	// Bölüm isimlerini listeye ekle
+	episodeNames := make([]string, 0, len(episodes))
+	for _, e := range episodes {
+		episodeNames = append(episodeNames, e.Title)
+	}
+
+	// Sezon indeksini belirle (filmler için her zaman 0)
+	return episodes, episodeNames, isMovie, sources.SeasonIndexOf(episodes[0]), nil
+}
+
+// seasonsOf, bölüm listesindeki farklı sezon numaralarını sıralı olarak döner.
+func seasonsOf(episodes []models.Episode) []int {
+	var seasons []int
+	for _, ep := range episodes {
+		sn := sources.SeasonIndexOf(ep) + 1
+		if !slices.Contains(seasons, sn) {
+			seasons = append(seasons, sn)
 		}
-		isMovie = *seasonData[0].IsMovie
 	}
+	sort.Ints(seasons)
+	return seasons
+}
 
+// buildWatchMenu, izleme menüsünü aktif kaynağın ve oynatıcının yeteneklerine göre oluşturur.
+// playlist, oynatıcının oynatma listesi açabilip açamadığını; inWatchlist, animenin izleme
+// listesinde olup olmadığına göre ekle/çıkar seçeneğini belirler.
+func buildWatchMenu(caps sources.Capabilities, isMovie bool, seasonCount int, playlist, inWatchlist bool) []string {
+	watchMenu := []string{"İzle"}
 	if !isMovie {
-		// Dizi ise bölüm verilerini al
-		episodes, err = source.GetEpisodesData(models.EpisodeParams{SeasonID: &selectedAnimeID, Slug: &selectedAnimeSlug})
-		if err != nil {
-			return nil, nil, false, 0, fmt.Errorf("bölüm verisi alınamadı: %w", err)
+		watchMenu = append(watchMenu, "Sonraki bölüm", "Önceki bölüm", "Bölüm seç")
+		if playlist {
+			watchMenu = append(watchMenu, "Oynatma listesi")
+		}
+		// Çok sezonlu kaynaklarda sezonlar arasında hızlı geçiş
+		if caps.Seasons && seasonCount > 1 {
+			watchMenu = append(watchMenu, "Sezon seç")
 		}
+	}
+	watchMenu = append(watchMenu, "Çözünürlük seç")
+
+	if caps.Fansubs {
+		watchMenu = append(watchMenu, "Fansub seç")
+	}
+	if caps.Subtitles {
+		watchMenu = append(watchMenu, "Altyazı seç")
+	}
+
+	if !isMovie {
+		watchMenu = append(watchMenu, "İzlendi olarak işaretle", "İzlenmedi olarak işaretle", "Intro/outro ayarla", "Bölüm indir")
+	} else {
+		watchMenu = append(watchMenu, "Movie indir")
+	}
+
+	if inWatchlist {
+		watchMenu = append(watchMenu, "İzleme listesinden çıkar")
+	} else {
+		watchMenu = append(watchMenu, "İzleme listesine ekle")
+	}
+
+	// Genel seçenekler
+	return append(watchMenu, "────────────────────", "Anime ara", "Çık")
+}
+
+// fetchSubtitles, altyazı sağlayan kaynaklar için seçili bölümün altyazılarını döner.
+func fetchSubtitles(ctx context.Context, source models.AnimeSource, episodes []models.Episode, index, id, seasonIndex int, isMovie bool, slug *string) ([]models.Subtitle, error) {
+	provider, ok := source.(models.SubtitleProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s altyazı seçimini desteklemiyor", source.Source())
+	}
+
+	episodeIndex := sources.EpisodeIndexInSeason(episodes, index, seasonIndex)
+	return provider.GetSubtitlesData(ctx, models.SubtitleParams{
+		Slug:         slug,
+		Id:           &id,
+		SeasonIndex:  &seasonIndex,
+		EpisodeIndex: &episodeIndex,
+		IsMovie:      &isMovie,
+	})
+}
+
+// playbackOptions, oynatıcı ve otomatik oynatma ayarlarıdır
+type playbackOptions struct {
+	player        player.Config // Kullanılacak oynatıcı
+	autoplay      bool          // Bölüm sonuna gelindiğinde sonraki bölüm otomatik oynatılsın mı?
+	autoplayLimit int           // Art arda otomatik oynatılacak en fazla bölüm (0: son bölüme kadar)
+}
+
+// autoplayCountdown, sonraki bölüm başlamadan önce beklenecek saniye
+const autoplayCountdown = 5
+
+// playlistEntry, oynatma listesindeki bir bölümün çözülmüş akışıdır
+type playlistEntry struct {
+	episodeIndex int    // Bölümün listedeki sırası
+	url          string // Seçilen çözünürlüğün video URL'si
+	subtitle     string // Altyazı URL'si (boş olabilir)
+	fansub       string // Oynatılan fansub (boş olabilir)
+}
+
+// episodePlayback, tek bir bölümün oynatılması için gereken bilgileri tutar
+type episodePlayback struct {
+	source         models.AnimeSource // Bölümün kaynağı
+	selectedSource string             // Kaynağın görünen adı (RPC için)
+	episodeNames   []string           // Tüm bölüm adları
+	episodeIndex   int                // Oynatılan bölümün indeksi
+	animeID        int                // Anime ID'si (AnimeciX için)
+	animeSlug      string             // Anime slug'ı (OpenAnime için)
+	animeName      string             // Anime adı
+	isMovie        bool               // Film mi dizi mi
+	url            string             // Seçilen çözünürlüğün video URL'si
+	subtitle       string             // Altyazı URL'si (boş olabilir)
+	fansub         string             // Oynatılan fansub (geçmişe kaydedilir, boş olabilir)
+	posterURL      string             // Poster görseli URL'si (Discord RPC için)
+	uiMode         string             // Arayüz tipi
+	rofiFlags      string             // Rofi için özel bayraklar
+	disableRPC     bool               // Discord RPC devre dışı mı?
+	player         player.Config      // Kullanılacak oynatıcı
+	playlist       []playlistEntry    // Doluysa bölümler tek oynatıcıda oynatma listesi olarak açılır
+	timestamp      time.Time          // Discord RPC timestamp
+	logger         *utils.Logger      // Logger
+}
 
-		if len(episodes) == 0 {
-			return nil, nil, false, 0, fmt.Errorf("hiçbir bölüm bulunamadı")
+// playEpisode, bölümü seçilen oynatıcı ile oynatır ve oynatma süresince Discord RPC ile geçmişi günceller.
+// p.playlist doluysa bölümler tek oynatıcıda oynatma listesi olarak açılır.
+// stop, yükleme göstergesini durdurur; oynatıcı başladığında ya da hata oluştuğunda çağrılır.
+// Oynatıcı videonun sonuna ulaşarak kapandıysa (kullanıcı kapatmadıysa) ended true döner.
+// Etkileşimli menü ve play alt komutu aynı oynatma akışını kullanır.
+func playEpisode(p episodePlayback, stop func()) (ended bool, err error) {
+	// Oynatıcı başlığı
+	titleOf := func(index int) string {
+		if p.isMovie {
+			return p.animeName
+		}
+		return fmt.Sprintf("%s - %s", p.animeName, p.episodeNames[index])
+	}
+
+	selectedAnimeId := historyAnimeID(p.source, p.animeID, p.animeSlug)
+	sourceID := sources.ID(p.source)
+
+	entries := p.playlist
+	isPlaylist := len(entries) > 0
+	if !isPlaylist {
+		entries = []playlistEntry{{episodeIndex: p.episodeIndex, url: p.url, subtitle: p.subtitle, fansub: p.fansub}}
+	}
+
+	// Bölüm daha önce yarıda bırakıldıysa kaldığı yerden devam etmeyi sor
+	start := 0.0
+	if progress, ok := utils.ResumePosition(sourceID, selectedAnimeId, entries[0].episodeIndex); ok {
+		stop() // spinneri durdur
+		answer, err := ui.SelectionList(internal.UiParams{
+			Mode:      p.uiMode,
+			RofiFlags: &p.rofiFlags,
+			List:      &[]string{"Evet", "Hayır"},
+			Label: fmt.Sprintf("Kaldığın yerden devam et? (%s / %s)",
+				formatDuration(progress.Position), formatDuration(progress.Duration)),
+		})
+		if err == nil && answer == "Evet" {
+			start = progress.Position
 		}
+	}
 
-		// Bölüm isimlerini listeye ekle
-		episodeNames = make([]string, 0, len(episodes))
-		for _, e := range episodes {
-			episodeNames = append(episodeNames, e.Title)
+	// Oynatıcıya verilecek videolar ve geçmiş/RPC için bölüm bilgileri
+	items := make([]player.Params, 0, len(entries))
+	historyEpisodes := make([]utils.PlaybackEpisode, 0, len(entries))
+	entryNames := make([]string, 0, len(entries))
+	for i, e := range entries {
+		subtitle := e.subtitle
+		item := player.Params{Url: e.url, SubtitleUrl: &subtitle, Title: titleOf(e.episodeIndex)}
+		if i == 0 {
+			item.Start = start
+		}
+		items = append(items, item)
+		historyEpisodes = append(historyEpisodes, utils.PlaybackEpisode{
+			Index:  e.episodeIndex,
+			Name:   p.episodeNames[e.episodeIndex],
+			Fansub: e.fansub,
+		})
+		entryNames = append(entryNames, p.episodeNames[e.episodeIndex])
+	}
+
+	// Oynatıcıyı oluştur ve başlat
+	pl, err := player.New(p.player)
+	if err == nil {
+		if isPlaylist {
+			err = startPlaylist(pl, items)
+		} else {
+			err = pl.Start(items[0])
 		}
+	}
+	if err != nil {
+		stop()           // spinneri durdur
+		ui.ClearScreen() // ekranı temizle
+		utils.CheckErr(internal.UiParams{
+			Mode:      p.uiMode,
+			RofiFlags: &p.rofiFlags,
+		}, err, p.logger)
+		return false, err
+	}
 
-		// Sezon indeksini belirle
-		selectedSeasonIndex = int(episodes[0].Extra["season_num"].(float64)) - 1
-	} else {
-		// Film ise sadece tek bir bölüm olarak ayarla
-		episodeNames = []string{selectedAnimeName}
-		episodes = []models.Episode{{
-			Title: selectedAnimeName,
-			Extra: map[string]interface{}{"season_num": float64(1)},
-		}}
-		selectedSeasonIndex = 0
+	// Loading spinner durdur
+	stop()
+
+	var stopCh chan struct{}
+	if !p.disableRPC {
+		stopCh = make(chan struct{}) // Goroutine'i durdurmak için kanal oluştur
+		go updateDiscordRPC(pl, entryNames, p.animeName, p.selectedSource, p.posterURL, p.timestamp, p.logger, stopCh)
 	}
 
-	return episodes, episodeNames, isMovie, selectedSeasonIndex, nil
+	// Intro/outro atlama ve işaretleme (mpv tabanlı oynatıcılar)
+	go utils.AutoSkip(pl, sourceID, selectedAnimeId, p.animeName, p.logger)
+
+	// History güncelleme için goroutine
+	go utils.UpdateAnimeHistory(pl, sourceID, selectedAnimeId, p.animeName, historyEpisodes, p.logger)
+
+	// Oynatma işlemi tamamlanana kadar bekle
+	err = pl.Wait()
+	if stopCh != nil {
+		// Oynatıcı kapandı → RPC goroutine'ini durdur
+		close(stopCh)
+	}
+	if err != nil {
+		err = fmt.Errorf("%s çalışırken hata: %w", pl.Name(), err)
+		p.logger.LogError(err)
+		return false, err
+	}
+	return player.Ended(pl), nil
+}
+
+// startPlaylist, oynatıcı destekliyorsa videoları oynatma listesi olarak başlatır
+func startPlaylist(pl player.Player, items []player.Params) error {
+	pp, ok := pl.(player.PlaylistPlayer)
+	if !ok {
+		return fmt.Errorf("%s oynatma listesini desteklemiyor", pl.Name())
+	}
+	err := pp.StartPlaylist(items)
+	if errors.Is(err, player.ErrUnsupported) {
+		return fmt.Errorf("%s oynatma listesini desteklemiyor", pl.Name())
+	}
+	return err
+}
+
+// resumeEpisodeIndex, geçmişe göre açılacak bölümün indeksini döner.
+// Son açılan bölüm yarıda bırakıldıysa o bölüm, izlendiyse bir sonraki bölüm,
+// hiç izlenmemişse ilk bölüm seçilir.
+func resumeEpisodeIndex(animeHistory utils.AnimeHistory, source models.AnimeSource, animeId string, episodeCount int) int {
+	entry := animeHistory[sources.ID(source)][animeId]
+
+	lastEpisodeIdx := -1
+	if entry.LastEpisodeIdx != nil {
+		lastEpisodeIdx = *entry.LastEpisodeIdx
+	}
+	// Bölüm kaydı olmayan eski geçmişlerde son bölüm izlenmiş kabul edilir
+	if record, ok := entry.Episodes[strconv.Itoa(lastEpisodeIdx)]; ok && !record.Watched && lastEpisodeIdx < episodeCount {
+		return lastEpisodeIdx
+	}
+	if lastEpisodeIdx >= 0 && episodeCount > lastEpisodeIdx+1 {
+		// Eğer daha önce izlenmişse bir sonraki bölüm
+		return lastEpisodeIdx + 1
+	}
+	return 0
 }
 
 // Seçilen animeyi oynatma döngüsünü yönetir.
@@ -909,75 +1465,60 @@ func playAnimeLoop(
 	rofiFlags string, // Rofi için özel bayraklar
 	posterURL string, // Poster görseli URL'si (Discord RPC için)
 	disableRPC bool, // Discord RPC devre dışı mı?
+	playback playbackOptions, // Oynatıcı ve otomatik oynatma ayarları
 	timestamp time.Time, // Discord RPC timestamp
 	animeHistory utils.AnimeHistory, // Geçmiş veri tipi
 	logger *utils.Logger, // Logger
 ) (models.AnimeSource, string, error) { // Geriye güncel kaynak ve kaynak ismi döner
 
-	selectedEpisodeIndex := 0
+	historyID := historyAnimeID(source, selectedAnimeID, selectedAnimeSlug)
+	selectedEpisodeIndex := resumeEpisodeIndex(animeHistory, source, historyID, len(episodes))
 	selectedFansubIdx := 0
 	selectedResolution := ""
 	selectedResolutionIdx := 0
+	selectedSubtitleLang := ""
 
-	lastEpisodeIdxP := animeHistory[strings.ToLower(source.Source())][selectedAnimeName].LastEpisodeIdx
+	autoplayNext := false // Bir sonraki turda menü gösterilmeden sonraki bölüm oynatılır
+	autoplayed := 0       // Art arda otomatik oynatılan bölüm sayısı
 
-	lastEpisodeIdx := -1
-	if lastEpisodeIdxP != nil {
-		lastEpisodeIdx = *lastEpisodeIdxP
-	}
-	if lastEpisodeIdx >= 0 && len(episodes) > lastEpisodeIdx+1 {
-		// Eğer daha önce izlenmişse bir sonraki bölüm
-		selectedEpisodeIndex = lastEpisodeIdx + 1
-	}
+	// Oynatma listesi yalnızca destekleyen oynatıcılarda menüde gösterilir
+	playlistSupported := player.SupportsPlaylist(playback.player)
 
 	for {
 		ui.ClearScreen()
 
-		// Kullanıcıya sunulacak menü seçenekleri
-		watchMenu := []string{}
-		if !isMovie {
-			watchMenu = append(watchMenu, "İzle", "Sonraki bölüm", "Önceki bölüm", "Bölüm seç", "Çözünürlük seç", "Bölüm indir")
-		} else {
-			watchMenu = append(watchMenu, "İzle", "Çözünürlük seç", "Movie indir")
-		}
+		// Kullanıcıya sunulacak menü seçenekleri kaynağın yeteneklerine göre oluşturulur
+		info, _ := sources.Lookup(source)
 
-		// OpenAnime için fansub seçimi
-		if strings.ToLower(selectedSource) == "openanime" {
-			idx := -1
-			for i, v := range watchMenu {
-				if v == "Bölüm indir" || v == "Movie indir" {
-					idx = i
-					break
-				}
-			}
+		var option string
+		if autoplayNext {
+			autoplayNext = false
+			option = "Sonraki bölüm"
+		} else {
+			autoplayed = 0
+			watchMenu := buildWatchMenu(info.Capabilities, isMovie, len(seasonsOf(episodes)), playlistSupported, utils.InWatchlist(sources.ID(source), historyID))
 
-			if idx != -1 {
-				watchMenu = append(watchMenu[:idx], append([]string{"Fansub seç"}, watchMenu[idx:]...)...)
+			// Menü başlığını hazırla - bölüm bilgisi ile
+			menuTitle := selectedAnimeName
+			if !isMovie {
+				currentEpisode := episodeNames[selectedEpisodeIndex]
+				menuTitle = fmt.Sprintf("%s ( %s )", selectedAnimeName, currentEpisode)
 			}
-		}
 
-		// Genel seçenekler
-		watchMenu = append(watchMenu, "────────────────────", "Anime ara", "Çık")
+			// Seçim arayüzünü göster
+			var err error
+			option, err = showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, watchMenu, menuTitle)
 
-		// Menü başlığını hazırla - bölüm bilgisi ile
-		menuTitle := selectedAnimeName
-		if !isMovie {
-			currentEpisode := episodeNames[selectedEpisodeIndex]
-			menuTitle = fmt.Sprintf("%s ( %s )", selectedAnimeName, currentEpisode)
-		}
-
-		// Seçim arayüzünü göster
-		option, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, watchMenu, menuTitle)
+			if errors.Is(err, tui.ErrGoBack) {
+				return nil, "", err
+			}
 
-		if errors.Is(err, tui.ErrGoBack) {
-			return nil, "", err
+			utils.FailIfErr(internal.UiParams{
+				Mode:      uiMode,
+				RofiFlags: &rofiFlags,
+			}, err, logger)
 		}
 
-		utils.FailIfErr(internal.UiParams{
-			Mode:      uiMode,
-			RofiFlags: &rofiFlags,
-		}, err, logger)
-
 		switch option {
 
 		// Oynatma ve bölüm gezme seçenekleri
@@ -999,18 +1540,18 @@ func playAnimeLoop(
 			}
 
 			// Loading spinner başlat
-			done := make(chan struct{})
-			go ui.ShowLoading(internal.UiParams{
+			ctx, stop := ui.Loading(context.Background(), internal.UiParams{
 				Mode:      uiMode,
 				RofiFlags: &rofiFlags,
-			}, "Başlatılıyor...", done)
+			}, "Başlatılıyor...")
 
 			// Güncel sezon bilgisi al
-			selectedSeasonIndex = int(episodes[selectedEpisodeIndex].Extra["season_num"].(float64)) - 1
+			selectedSeasonIndex = sources.SeasonIndexOf(episodes[selectedEpisodeIndex])
 
 			// API'den oynatma bilgilerini güncelle
-			data, _, err := updateWatchAPI(
-				strings.ToLower(selectedSource),
+			data, fansubs, err := updateWatchAPI(
+				ctx,
+				source,
 				episodes,
 				selectedEpisodeIndex,
 				selectedAnimeID,
@@ -1020,8 +1561,11 @@ func playAnimeLoop(
 				&selectedAnimeSlug,
 			)
 			if err != nil {
-				close(done)      // spinneri durdur
+				stop()           // spinneri durdur
 				ui.ClearScreen() // ekranı temizle
+				if errors.Is(err, context.Canceled) {
+					continue
+				}
 				fmt.Printf("\033[31m[!] Bölüm oynatılamadı: %s\033[0m\n", err)
 				time.Sleep(1500 * time.Millisecond)
 				continue
@@ -1031,6 +1575,20 @@ func playAnimeLoop(
 			urls := data["urls"].([]string)
 			subtitle := data["caption_url"].(string)
 
+			// Kullanıcı farklı bir altyazı dili seçtiyse onu kullan
+			if selectedSubtitleLang != "" {
+				subtitles, err := fetchSubtitles(ctx, source, episodes, selectedEpisodeIndex, selectedAnimeID, selectedSeasonIndex, isMovie, &selectedAnimeSlug)
+				if err != nil {
+					logger.LogError(err)
+				}
+				for _, sub := range subtitles {
+					if sub.Language == selectedSubtitleLang {
+						subtitle = sub.Url
+						break
+					}
+				}
+			}
+
 			// Varsayılan çözünürlük seçimi
 			if selectedResolution == "" {
 				selectedResolutionIdx = 0
@@ -1042,89 +1600,65 @@ func playAnimeLoop(
 				selectedResolutionIdx = len(urls) - 1
 			}
 
-			// MPV başlığı ayarla
-			mpvTitle := fmt.Sprintf("%s - %s", selectedAnimeName, episodeNames[selectedEpisodeIndex])
-			if isMovie {
-				mpvTitle = selectedAnimeName
+			// Kullanıcı yüklemeyi iptal ettiyse oynatmadan menüye dön
+			if ctx.Err() != nil {
+				stop()
+				continue
 			}
 
-			// MPV ile oynat
-			cmd, socketPath, err := player.Play(player.MPVParams{
-				Url:         urls[selectedResolutionIdx],
-				SubtitleUrl: &subtitle,
-				Title:       mpvTitle,
-			})
-			if !utils.CheckErr(internal.UiParams{
-				Mode:      uiMode,
-				RofiFlags: &rofiFlags,
-			}, err, logger) {
-				close(done) // spinneri durdur
+			// Oynatıcı ile oynat; RPC ve geçmiş oynatma süresince güncellenir
+			ended, err := playEpisode(episodePlayback{
+				source:         source,
+				selectedSource: selectedSource,
+				episodeNames:   episodeNames,
+				episodeIndex:   selectedEpisodeIndex,
+				animeID:        selectedAnimeID,
+				animeSlug:      selectedAnimeSlug,
+				animeName:      selectedAnimeName,
+				isMovie:        isMovie,
+				url:            urls[selectedResolutionIdx],
+				subtitle:       subtitle,
+				fansub:         fansubName(fansubs, selectedFansubIdx),
+				posterURL:      posterURL,
+				uiMode:         uiMode,
+				rofiFlags:      rofiFlags,
+				disableRPC:     disableRPC,
+				player:         playback.player,
+				timestamp:      timestamp,
+				logger:         logger,
+			}, stop)
+			if err != nil {
 				return source, selectedSource, err
 			}
 
-			// MPV’nin çalışıp çalışmadığını kontrol et
-			maxAttempts := 10
-			mpvRunning := false
-			for i := 0; i < maxAttempts; i++ {
-				time.Sleep(300 * time.Millisecond)
-				if player.IsMPVRunning(socketPath) {
-					mpvRunning = true
+			// Bölüm sonuna kadar izlendiyse sıradaki bölüme geri sayımla geç
+			if ended && playback.autoplay && !isMovie && selectedEpisodeIndex+1 < len(episodes) {
+				if playback.autoplayLimit > 0 && autoplayed >= playback.autoplayLimit {
 					break
 				}
-			}
-			if !mpvRunning {
-				close(done)      // spinneri durdur
-				ui.ClearScreen() // ekranı temizle
-				err := fmt.Errorf("MPV başlatılamadı veya zamanında yanıt vermedi")
-				logger.LogError(err)
-				return source, selectedSource, err
-			}
-
-			// Loading spinner durdur
-			close(done)
-
-			var stopCh chan struct{}
-			if !disableRPC {
-				stopCh = make(chan struct{}) // Goroutine'i durdurmak için kanal oluştur
-				go updateDiscordRPC(socketPath, episodeNames, selectedEpisodeIndex, selectedAnimeName, selectedSource, posterURL, timestamp, logger, stopCh)
-			}
-
-			var selectedAnimeId string
-
-			if strings.ToLower(source.Source()) == "animecix" {
-				selectedAnimeId = strconv.Itoa(selectedAnimeID)
-			} else {
-				selectedAnimeId = selectedAnimeSlug
-			}
-
-			// History güncelleme için goroutine
-			go utils.UpdateAnimeHistory(socketPath, strings.ToLower(source.Source()), selectedAnimeName, episodeNames[selectedEpisodeIndex], selectedAnimeId, selectedEpisodeIndex, logger)
-
-			// Oynatma işlemi tamamlanana kadar bekle
-			err = cmd.Wait()
-			if err != nil {
-				err = fmt.Errorf("MPV çalışırken hata: %w", err)
-				logger.LogError(err)
-				return source, selectedSource, err
-			}
-
-			if stopCh != nil {
-				// MPV kapandı → RPC goroutine'ini durdur
-				close(stopCh)
+				ui.ClearScreen()
+				autoplayNext = ui.Countdown(internal.UiParams{
+					Mode:      uiMode,
+					RofiFlags: &rofiFlags,
+					Label:     fmt.Sprintf("Sonraki bölüm başlıyor: %s", episodeNames[selectedEpisodeIndex+1]),
+				}, autoplayCountdown)
+				if autoplayNext {
+					autoplayed++
+				}
 			}
 
 		// Çözünürlük seçme ekranı
 		case "Çözünürlük seç":
 
 			// Loading spinner başlat
-			done := make(chan struct{})
-			go ui.ShowLoading(internal.UiParams{
+			ctx, stop := ui.Loading(context.Background(), internal.UiParams{
 				Mode:      uiMode,
 				RofiFlags: &rofiFlags,
-			}, "Hazırlanıyor...", done)
+			}, "Hazırlanıyor...")
 
 			data, _, err := updateWatchAPI(
-				strings.ToLower(selectedSource),
+				ctx,
+				source,
 				episodes,
 				selectedEpisodeIndex,
 				selectedAnimeID,
@@ -1134,9 +1668,12 @@ func playAnimeLoop(
 				&selectedAnimeSlug,
 			)
 			if err != nil {
-				close(done)      // spinneri durdur
+				stop()           // spinneri durdur
 				ui.ClearScreen() // ekranı temizle
 
+				if errors.Is(err, context.Canceled) {
+					continue
+				}
 				fmt.Printf("\033[31m[!] Çözünürlükler yüklenemedi.\033[0m\n")
 				time.Sleep(1000 * time.Millisecond)
 				continue
@@ -1144,7 +1681,7 @@ func playAnimeLoop(
 			labels := data["labels"].([]string)
 
 			// Loading spinner durdur
-			close(done)
+			stop()
 
 			selected, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, labels, "Çözünürlük seç ")
 
@@ -1158,17 +1695,119 @@ func playAnimeLoop(
 			}, err, logger) {
 				continue
 			}
-			selectedResolution = selected
-			if !slices.Contains(labels, selected) {
-				fmt.Printf("\033[31m[!] Geçersiz çözünürlük seçimi: %s\033[0m\n", selected)
+			selectedResolution = selected
+			if !slices.Contains(labels, selected) {
+				fmt.Printf("\033[31m[!] Geçersiz çözünürlük seçimi: %s\033[0m\n", selected)
+				time.Sleep(1500 * time.Millisecond)
+				continue
+			}
+			selectedResolutionIdx = slices.Index(labels, selected)
+
+		// Bölüm seçimi
+		case "Bölüm seç":
+			idx, err := selectEpisode(uiMode, rofiFlags, sources.ID(source), historyID, episodeNames, "Bölüm seç ")
+
+			if errors.Is(err, tui.ErrGoBack) {
+				continue
+			}
+
+			if !utils.CheckErr(internal.UiParams{
+				Mode:      uiMode,
+				RofiFlags: &rofiFlags,
+			}, err, logger) {
+				continue
+			}
+			if idx < 0 {
+				continue
+			}
+			selectedEpisodeIndex = idx
+			if !isMovie && selectedEpisodeIndex < len(episodes) {
+				selectedSeasonIndex = sources.SeasonIndexOf(episodes[selectedEpisodeIndex])
+			}
+
+		// Seçilen bölümden başlayarak birden fazla bölümü tek oynatıcıda oynat
+		case "Oynatma listesi":
+			remaining := episodeNames[selectedEpisodeIndex:]
+			selected, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, remaining, "Son bölümü seç ")
+
+			if errors.Is(err, tui.ErrGoBack) {
+				continue
+			}
+
+			if !utils.CheckErr(internal.UiParams{
+				Mode:      uiMode,
+				RofiFlags: &rofiFlags,
+			}, err, logger) {
+				continue
+			}
+			if !slices.Contains(remaining, selected) {
+				continue
+			}
+			lastIndex := selectedEpisodeIndex + slices.Index(remaining, selected)
+
+			// Loading spinner başlat
+			ctx, stop := ui.Loading(context.Background(), internal.UiParams{
+				Mode:      uiMode,
+				RofiFlags: &rofiFlags,
+			}, "Bölümler hazırlanıyor...")
+
+			entries, err := resolvePlaylist(
+				ctx, source, episodes, selectedEpisodeIndex, lastIndex,
+				selectedAnimeID, &selectedAnimeSlug, selectedFansubIdx,
+				selectedResolution, selectedSubtitleLang,
+			)
+			if err != nil {
+				stop()           // spinneri durdur
+				ui.ClearScreen() // ekranı temizle
+				if errors.Is(err, context.Canceled) {
+					continue
+				}
+				fmt.Printf("\033[31m[!] Oynatma listesi hazırlanamadı: %s\033[0m\n", err)
 				time.Sleep(1500 * time.Millisecond)
 				continue
 			}
-			selectedResolutionIdx = slices.Index(labels, selected)
 
-		// Bölüm seçimi
-		case "Bölüm seç":
-			selected, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, episodeNames, "Bölüm seç ")
+			_, err = playEpisode(episodePlayback{
+				source:         source,
+				selectedSource: selectedSource,
+				episodeNames:   episodeNames,
+				episodeIndex:   selectedEpisodeIndex,
+				animeID:        selectedAnimeID,
+				animeSlug:      selectedAnimeSlug,
+				animeName:      selectedAnimeName,
+				url:            entries[0].url,
+				subtitle:       entries[0].subtitle,
+				posterURL:      posterURL,
+				uiMode:         uiMode,
+				rofiFlags:      rofiFlags,
+				disableRPC:     disableRPC,
+				player:         playback.player,
+				playlist:       entries,
+				timestamp:      timestamp,
+				logger:         logger,
+			}, stop)
+			if err != nil {
+				time.Sleep(1500 * time.Millisecond)
+				continue
+			}
+
+			// Geçmişe göre sıradaki bölüme geç
+			if history, err := utils.ReadAnimeHistory(); err == nil {
+				if next := resumeEpisodeIndex(history, source, historyID, len(episodes)); next > selectedEpisodeIndex {
+					selectedEpisodeIndex = next
+					selectedSeasonIndex = sources.SeasonIndexOf(episodes[selectedEpisodeIndex])
+				}
+			}
+
+		// Sezon seçimi (çok sezonlu kaynaklar için)
+		case "Sezon seç":
+			seasons := seasonsOf(episodes)
+			seasonNames := make([]string, 0, len(seasons))
+			for _, sn := range seasons {
+				seasonNames = append(seasonNames, fmt.Sprintf("%d. Sezon", sn))
+			}
+
+			selected, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, seasonNames, "Sezon seç ")
 
 			if errors.Is(err, tui.ErrGoBack) {
 				continue
@@ -1180,37 +1819,40 @@ func playAnimeLoop(
 			}, err, logger) {
 				continue
 			}
-			if slices.Contains(episodeNames, selected) {
-				selectedEpisodeIndex = slices.Index(episodeNames, selected)
-				if !isMovie && selectedEpisodeIndex >= 0 && selectedEpisodeIndex < len(episodes) {
-					selectedSeasonIndex = int(episodes[selectedEpisodeIndex].Extra["season_num"].(float64)) - 1
-				}
-			} else {
+
+			if !slices.Contains(seasonNames, selected) {
 				continue
 			}
 
-		// Fansub seçimi (yalnızca OpenAnime için)
+			// Seçilen sezonun ilk bölümüne geç
+			seasonIndex := seasons[slices.Index(seasonNames, selected)] - 1
+			for i, ep := range episodes {
+				if sources.SeasonIndexOf(ep) == seasonIndex {
+					selectedEpisodeIndex = i
+					selectedSeasonIndex = seasonIndex
+					break
+				}
+			}
+
+		// Fansub seçimi (fansub destekleyen kaynaklar için)
 		case "Fansub seç":
+			if !info.Capabilities.Fansubs {
+				fmt.Printf("\033[31m[!] %s fansub seçimini desteklemiyor.\033[0m\n", info.Name)
+				time.Sleep(1500 * time.Millisecond)
+				continue
+			}
+
 			// Loading spinner başlat
-			done := make(chan struct{})
-			go ui.ShowLoading(internal.UiParams{
+			ctx, stop := ui.Loading(context.Background(), internal.UiParams{
 				Mode:      uiMode,
 				RofiFlags: &rofiFlags,
-			}, "Hazırlanıyor...", done)
+			}, "Hazırlanıyor...")
 
 			fansubNames := []string{}
 
-			if strings.ToLower(source.Source()) != "openanime" {
-				close(done)      // spinneri durdur
-				ui.ClearScreen() // ekranı temizle
-
-				fmt.Println("\033[31m[!] Bu seçenek sadece OpenAnime için geçerlidir.\033[0m")
-				time.Sleep(1500 * time.Millisecond)
-				continue
-			}
-
 			_, fansubData, err := updateWatchAPI(
-				strings.ToLower(selectedSource),
+				ctx,
+				source,
 				episodes,
 				selectedEpisodeIndex,
 				selectedAnimeID,
@@ -1220,9 +1862,12 @@ func playAnimeLoop(
 				&selectedAnimeSlug,
 			)
 			if err != nil {
-				close(done)      // spinneri durdur
+				stop()           // spinneri durdur
 				ui.ClearScreen() // ekranı temizle
 
+				if errors.Is(err, context.Canceled) {
+					continue
+				}
 				fmt.Printf("\033[31m[!] Fansublar yüklenemedi.\033[0m\n")
 				time.Sleep(1000 * time.Millisecond)
 				continue
@@ -1235,7 +1880,7 @@ func playAnimeLoop(
 			}
 
 			// Loading spinner durdur
-			close(done)
+			stop()
 
 			selected, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, fansubNames, "Fansub seç ")
 
@@ -1257,6 +1902,97 @@ func playAnimeLoop(
 			}
 			selectedFansubIdx = slices.Index(fansubNames, selected)
 
+		// Altyazı seçimi (altyazı sağlayan kaynaklar için)
+		case "Altyazı seç":
+			// Loading spinner başlat
+			ctx, stop := ui.Loading(context.Background(), internal.UiParams{
+				Mode:      uiMode,
+				RofiFlags: &rofiFlags,
+			}, "Hazırlanıyor...")
+
+			subtitles, err := fetchSubtitles(ctx, source, episodes, selectedEpisodeIndex, selectedAnimeID, selectedSeasonIndex, isMovie, &selectedAnimeSlug)
+			if err != nil || len(subtitles) == 0 {
+				stop()           // spinneri durdur
+				ui.ClearScreen() // ekranı temizle
+
+				if errors.Is(err, context.Canceled) {
+					continue
+				}
+				logger.LogError(err)
+				fmt.Printf("\033[31m[!] Altyazılar yüklenemedi.\033[0m\n")
+				time.Sleep(1000 * time.Millisecond)
+				continue
+			}
+
+			languages := make([]string, 0, len(subtitles))
+			for _, sub := range subtitles {
+				if sub.Language != "" && !slices.Contains(languages, sub.Language) {
+					languages = append(languages, sub.Language)
+				}
+			}
+
+			// Loading spinner durdur
+			stop()
+
+			selected, err := showSelection(App{uiMode: &uiMode, rofiFlags: &rofiFlags}, languages, "Altyazı seç ")
+
+			if errors.Is(err, tui.ErrGoBack) {
+				continue
+			}
+
+			if !utils.CheckErr(internal.UiParams{
+				Mode:      uiMode,
+				RofiFlags: &rofiFlags,
+			}, err, logger) {
+				continue
+			}
+
+			if !slices.Contains(languages, selected) {
+				fmt.Printf("\033[31m[!] Geçersiz altyazı seçimi: %s\033[0m\n", selected)
+				time.Sleep(1500 * time.Millisecond)
+				continue
+			}
+			selectedSubtitleLang = selected
+
+		// Bölümleri elle izlendi/izlenmedi olarak işaretle
+		case "İzlendi olarak işaretle", "İzlenmedi olarak işaretle":
+			watched := option == "İzlendi olarak işaretle"
+			if err := markEpisodes(uiMode, rofiFlags, sources.ID(source), historyID, selectedAnimeName, episodeNames, watched); err != nil {
+				if errors.Is(err, tui.ErrGoBack) {
+					continue
+				}
+				fmt.Printf("\033[31m[!] Bölümler işaretlenemedi: %s\033[0m\n", err)
+				time.Sleep(1500 * time.Millisecond)
+			}
+
+		// Intro/outro aralıklarını elle kaydet ya da sil
+		case "Intro/outro ayarla":
+			if err := editAnimeSkips(uiMode, rofiFlags, sources.ID(source), historyID, selectedAnimeName); err != nil {
+				if errors.Is(err, tui.ErrGoBack) {
+					continue
+				}
+				fmt.Printf("\033[31m[!] %s\033[0m\n", err)
+				time.Sleep(1500 * time.Millisecond)
+			}
+
+		// Yayınlanan yeni bölümleri takip etmek için izleme listesine ekle ya da çıkar
+		case "İzleme listesine ekle":
+			entry := utils.WatchlistEntry{Title: selectedAnimeName, IsMovie: isMovie}
+			if posterURL != "anitrcli" {
+				entry.Poster = posterURL
+			}
+			err := utils.AddToWatchlist(sources.ID(source), historyID, entry)
+			if err != nil {
+				fmt.Printf("\033[31m[!] İzleme listesine eklenemedi: %s\033[0m\n", err)
+				time.Sleep(1500 * time.Millisecond)
+			}
+
+		case "İzleme listesinden çıkar":
+			if err := utils.RemoveFromWatchlist(sources.ID(source), historyID); err != nil {
+				fmt.Printf("\033[31m[!] İzleme listesinden çıkarılamadı: %s\033[0m\n", err)
+				time.Sleep(1500 * time.Millisecond)
+			}
+
 		// Movie / Bölüm indir
 		case "Bölüm indir", "Movie indir":
 			ui.ClearScreen()
@@ -1277,13 +2013,10 @@ func playAnimeLoop(
 				cfg.DownloadDir = input
 
 				// Config dosyasına kaydet
-				os.MkdirAll(utils.ConfigDir(), 0o755)
-				f, err := os.Create(filepath.Join(utils.ConfigDir(), "config.json"))
-				if err == nil {
-					defer f.Close()
-					enc := json.NewEncoder(f)
-					enc.SetIndent("", "  ")
-					enc.Encode(cfg)
+				if err := utils.UpdateConfig(filepath.Join(utils.ConfigDir(), "config.json"), func(c *utils.Config) {
+					c.DownloadDir = input
+				}); err != nil {
+					logger.LogError(err)
 				}
 			}
 
@@ -1342,19 +2075,19 @@ func playAnimeLoop(
 
 			// Güncel sezon bilgisi
 			if len(selectedEpisodes) > 0 {
-				selectedSeasonIndex = int(selectedEpisodes[0].Extra["season_num"].(float64)) - 1
+				selectedSeasonIndex = sources.SeasonIndexOf(selectedEpisodes[0])
 			}
 
 			// Loading spinner başlat
-			done := make(chan struct{})
-			go ui.ShowLoading(internal.UiParams{
+			ctx, stop := ui.Loading(context.Background(), internal.UiParams{
 				Mode:      uiMode,
 				RofiFlags: &rofiFlags,
-			}, "İndiriliyor...", done)
+			}, "İndiriliyor...")
 
 			// Seçilen çözünürlüğe göre tüm bölümlerin URL'lerini al
 			links, err := getSelectedEpidodesLinks(
-				strings.ToLower(selectedSource),
+				ctx,
+				source,
 				selectedEpisodes,
 				selectedFansubIdx,
 				isMovie,
@@ -1363,16 +2096,19 @@ func playAnimeLoop(
 				selectedAnimeID,
 			)
 			if err != nil {
-				close(done)      // spinneri durdur
+				stop()           // spinneri durdur
 				ui.ClearScreen() // ekranı temizle
 
+				if errors.Is(err, context.Canceled) {
+					continue
+				}
 				fmt.Printf("\033[31m[!] Bölüm URL'leri alınamadı: %s\033[0m\n", err)
 				time.Sleep(1500 * time.Millisecond)
 				continue
 			}
 
 			// Loading spinner durdur
-			close(done)
+			stop()
 			// Yazıyı temizle
 			ui.ClearScreen()
 
@@ -1390,12 +2126,13 @@ func playAnimeLoop(
 					continue
 				}
 
-				seasonNumber, ok := ep.Extra["season_num"].(float64)
+				seasonNumber, ok := sources.ExtraInt(ep.Extra, "season_num")
 				if !ok {
-					logger.LogError(fmt.Errorf("season_num float64 değil"))
+					fmt.Printf("\033[31m[!] %s için sezon numarası bulunamadı.\033[0m\n", ep.Title)
+					continue
 				}
 
-				err = downloader.Download(strings.ToLower(source.Source()), selectedAnimeName, url, episodeNumber, int(seasonNumber))
+				err = downloader.Download(sources.ID(source), selectedAnimeName, url, episodeNumber, seasonNumber)
 				if err != nil {
 					fmt.Printf("\033[31m[!] %s indirilemedi: %s\033[0m\n", ep.Title, err)
 				}
@@ -1441,70 +2178,88 @@ func playAnimeLoop(
 	}
 }
 
-// Discord RPC'yi güncelleyerek anime oynatma durumunu Discord'a yansıtır
-func updateDiscordRPC(socketPath string, episodeNames []string, selectedEpisodeIndex int,
+// formatDuration, saniyeyi "dd:ss" ya da "ss:dd:ss" biçiminde yazar
+func formatDuration(seconds float64) string {
+	total := int(seconds + 0.5)
+	hours := total / 3600
+	minutes := (total % 3600) / 60
+	secs := total % 60
+	if hours > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, secs)
+	}
+	return fmt.Sprintf("%02d:%02d", minutes, secs)
+}
+
+// rpcInterval, konum değişikliklerinde Discord RPC'nin en sık güncellenme aralığıdır
+const rpcInterval = 5 * time.Second
+
+// Discord RPC'yi güncelleyerek anime oynatma durumunu Discord'a yansıtır.
+// Oynatıcının olay akışını dinler; duraklatma değişiklikleri hemen, konum değişiklikleri
+// en fazla rpcInterval'de bir yansıtılır. Oynatıcı ilerleme bildiremiyorsa durum
+// yalnızca bölüm adıyla gösterilir.
+func updateDiscordRPC(pl player.Player, entryNames []string,
 	selectedAnimeName, selectedSource, posterURL string, timestamp time.Time, logger *utils.Logger, stopCh <-chan struct{},
 ) {
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
+	defer rpc.ClientLogout()
+
+	update := func(state string) {
+		params := internal.RPCParams{
+			Type:       3,
+			Details:    selectedAnimeName,
+			State:      state,
+			SmallImage: strings.ToLower(selectedSource),
+			SmallText:  selectedSource,
+			LargeImage: posterURL,
+			LargeText:  selectedAnimeName,
+			Timestamp:  timestamp,
+		}
+		if err := rpc.DiscordRPC(params); err != nil {
+			logger.LogError(fmt.Errorf("DiscordRPC hatası: %w", err))
+		}
+	}
+
+	// entryName, oynatma listesindeki sıraya karşılık gelen bölüm adını döner
+	entryName := func(st player.State) string {
+		if st.PlaylistPos >= 0 && st.PlaylistPos < len(entryNames) {
+			return entryNames[st.PlaylistPos]
+		}
+		return entryNames[0]
+	}
+
+	// İlerleme bilgisi yoksa yalnızca bölüm adı gösterilir
+	if _, err := pl.Position(); errors.Is(err, player.ErrUnsupported) {
+		update(entryNames[0])
+	}
+
+	events, cancel := player.Subscribe(pl)
+	defer cancel()
 
+	var lastUpdate time.Time
 	for {
 		select {
 		case <-stopCh:
 			// Stop sinyali geldi → Discord RPC'yi kapat
-			rpc.ClientLogout()
 			return
-		case <-ticker.C:
-			// Eğer MPV çalışmıyorsa da RPC'yi kapat ve çık
-			if !player.IsMPVRunning(socketPath) {
-				rpc.ClientLogout()
+		case ev, ok := <-events:
+			// Oynatıcı kapandıysa RPC'yi kapat ve çık
+			if !ok {
 				return
 			}
 
-			// MPV duraklatma durumu
-			isPaused, _ := player.GetMPVPausedStatus(socketPath)
-
-			// MPV süre ve konum
-			durationVal, _ := player.MPVSendCommand(socketPath, []interface{}{"get_property", "duration"})
-			timePosVal, _ := player.MPVSendCommand(socketPath, []interface{}{"get_property", "time-pos"})
-			duration, ok1 := durationVal.(float64)
-			timePos, ok2 := timePosVal.(float64)
-			if !ok1 || !ok2 {
-				fmt.Println("süre veya zaman konumu parse edilemedi")
+			st := ev.State
+			if st.Duration <= 0 {
 				continue
 			}
-
-			formatTime := func(seconds float64) string {
-				total := int(seconds + 0.5)
-				hours := total / 3600
-				minutes := (total % 3600) / 60
-				secs := total % 60
-				if hours > 0 {
-					return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, secs)
-				}
-				return fmt.Sprintf("%02d:%02d", minutes, secs)
+			if ev.Type == player.EventPosition && time.Since(lastUpdate) < rpcInterval {
+				continue
 			}
+			lastUpdate = time.Now()
 
-			state := fmt.Sprintf("%s (%s / %s)", episodeNames[selectedEpisodeIndex], formatTime(timePos), formatTime(duration))
-			if isPaused {
+			state := fmt.Sprintf("%s (%s / %s)", entryName(st), formatDuration(st.Position), formatDuration(st.Duration))
+			if st.Paused {
 				state += " (Paused)"
 			}
-
-			params := internal.RPCParams{
-				Type:       3,
-				Details:    selectedAnimeName,
-				State:      state,
-				SmallImage: strings.ToLower(selectedSource),
-				SmallText:  selectedSource,
-				LargeImage: posterURL,
-				LargeText:  selectedAnimeName,
-				Timestamp:  timestamp,
-			}
-
-			if err := rpc.DiscordRPC(params); err != nil {
-				logger.LogError(fmt.Errorf("DiscordRPC hatası: %w", err))
-				continue
-			}
+			update(state)
 		}
 	}
 }
@@ -1516,9 +2271,14 @@ type App struct {
 	uiMode         *string
 	rofiFlags      *string
 	disableRPC     *bool
+	playback       playbackOptions
 	animeHistory   *utils.AnimeHistory
 	historyLimit   int
 	logger         *utils.Logger
+
+	historyAllSources bool // Geçmiş menüsünde tüm kaynakların kayıtları gösterilir
+
+	watchlist *watchlistState // İzleme listesinin son yeni bölüm denetimi
 }
 
 // Kullanıcıdan bir seçim almak için kullanılan fonksiyon
@@ -1544,22 +2304,21 @@ func app(cfx *App, timestamp time.Time) error {
 		isMovie := false
 
 		// Kullanıcıdan anime seçimi yapılması istenir
-		selectedAnime, isMovie, animeidx := selectAnime(animeNames, searchData, *cfx.uiMode, isMovie, *cfx.rofiFlags, animeTypes, cfx.logger)
+		selectedAnime, isMovie, animeidx := selectAnime(*cfx.source, animeNames, searchData, *cfx.uiMode, isMovie, *cfx.rofiFlags, animeTypes, cfx.logger)
 
 		if animeidx == -1 {
 			continue
 		}
 
 		// Loading spinner başlat
-		done := make(chan struct{})
-		go ui.ShowLoading(internal.UiParams{
+		ctx, stop := ui.Loading(context.Background(), internal.UiParams{
 			Mode:      *cfx.uiMode,
 			RofiFlags: cfx.rofiFlags,
-		}, "Yükleniyor...", done)
+		}, "Yükleniyor...")
 
 		// Poster URL'si alınır ve geçersizse varsayılan bir URL kullanılır
 		posterURL := selectedAnime.ImageURL
-		if !utils.IsValidImage(posterURL) {
+		if !utils.IsValidImage(ctx, posterURL) {
 			posterURL = "anitrcli"
 		}
 
@@ -1568,12 +2327,16 @@ func app(cfx *App, timestamp time.Time) error {
 
 		// Anime bölümleri alınır
 		episodes, episodeNames, isMovie, selectedSeasonIndex, err := getEpisodesAndNames(
-			*cfx.source, isMovie, selectedAnimeID, selectedAnimeSlug, selectedAnime.Title,
+			ctx, *cfx.source, isMovie, selectedAnimeID, selectedAnimeSlug, selectedAnime.Title,
 		)
 		// Hata durumunda kullanıcıya seçenek sunulur
 		if err != nil {
 			// Loading spinner durdur
-			close(done)
+			stop()
+			// Kullanıcı yüklemeyi iptal ettiyse aramaya dön
+			if errors.Is(err, context.Canceled) {
+				continue
+			}
 			// Hatayı logla
 			cfx.logger.LogError(err)
 
@@ -1597,14 +2360,14 @@ func app(cfx *App, timestamp time.Time) error {
 		}
 
 		// Loading spinneri durdur
-		close(done)
+		stop()
 
 		// Oynatma döngüsüne girilir
 		newSource, newSelectedSource, err := playAnimeLoop(
 			*cfx.source, *cfx.selectedSource, episodes, episodeNames,
 			selectedAnimeID, selectedAnimeSlug, selectedAnime.Title,
 			isMovie, selectedSeasonIndex, *cfx.uiMode, *cfx.rofiFlags,
-			posterURL, *cfx.disableRPC, timestamp, *cfx.animeHistory, cfx.logger,
+			posterURL, *cfx.disableRPC, cfx.playback, timestamp, *cfx.animeHistory, cfx.logger,
 		)
 
 		if errors.Is(err, tui.ErrGoBack) {
@@ -1631,68 +2394,81 @@ func quickResumeLastAnime(cfx *App, timestamp time.Time) error {
 	// En son izlenen animeyi bul
 	var latestAnime string
 	var latestAnimeId string
-	var latestEpisodeIdx int
 	var latestSource string
 	var latestTime time.Time
 
 	for sourceName, sourceData := range *cfx.animeHistory {
-		for animeName, entry := range sourceData {
-			if entry.LastWatched != nil && entry.LastWatched.After(latestTime) {
+		for animeId, entry := range sourceData {
+			if entry.LastWatched == nil || entry.LastEpisodeIdx == nil {
+				continue
+			}
+			if entry.LastWatched.After(latestTime) {
 				latestTime = *entry.LastWatched
-				latestAnime = animeName
-				latestAnimeId = *entry.AnimeId
-				latestEpisodeIdx = *entry.LastEpisodeIdx
+				latestAnime = entry.Title
+				latestAnimeId = animeId
 				latestSource = sourceName
 			}
 		}
 	}
 
-	if latestAnime == "" {
+	if latestAnimeId == "" {
 		return fmt.Errorf("geçmişte anime bulunamadı")
 	}
 
-	// Kaynağı ayarla
-	var source models.AnimeSource
-	switch strings.ToLower(latestSource) {
-	case "openanime":
-		source = openanime.OpenAnime{}
-		cfx.selectedSource = utils.Ptr("OpenAnime")
-	case "animecix":
-		source = animecix.AnimeCix{}
-		cfx.selectedSource = utils.Ptr("AnimeciX")
-	default:
-		return fmt.Errorf("geçersiz kaynak: %s", latestSource)
+	fmt.Printf(" Son izlenen anime devam ettiriliyor: %s\n", latestAnime)
+	return openSavedAnime(cfx, timestamp, latestSource, latestAnimeId)
+}
+
+// openSavedAnime, geçmişte ya da izleme listesinde kayıtlı animeyi kaynağı ve ID'siyle
+// (slug kullanan kaynaklarda slug) açar. Aktif kaynak animenin kaynağına geçirilir ve
+// oynatma geçmişe göre sıradaki bölümden başlar.
+func openSavedAnime(cfx *App, timestamp time.Time, sourceID, animeId string) error {
+	// Kaynağı kayıt defterinden ayarla
+	info, ok := sources.Get(sourceID)
+	if !ok {
+		return fmt.Errorf("geçersiz kaynak: %s", sourceID)
 	}
+	source := info.Source
 	cfx.source = &source
+	cfx.selectedSource = utils.Ptr(info.Name)
 
-	fmt.Printf(" Son izlenen anime devam ettiriliyor: %s\n", latestAnime)
+	// Loading spinner başlat
+	ctx, stop := ui.Loading(context.Background(), internal.UiParams{
+		Mode:      *cfx.uiMode,
+		RofiFlags: cfx.rofiFlags,
+	}, "Yükleniyor...")
 
 	// Anime bilgilerini al
-	animeData, err := source.GetAnimeByID(latestAnimeId)
+	animeData, err := source.GetAnimeByID(ctx, animeId)
 	if err != nil {
+		stop()
 		return fmt.Errorf("anime bilgileri alınamadı: %w", err)
 	}
+	if info.Capabilities.Slug && animeData.Slug == nil {
+		animeData.Slug = &animeId
+	}
 
 	// Anime ID ve slug'ını al
 	selectedAnimeID, selectedAnimeSlug := getAnimeIDs(source, *animeData)
 
 	// Poster URL'si
 	posterURL := animeData.ImageURL
-	if !utils.IsValidImage(posterURL) {
+	if !utils.IsValidImage(ctx, posterURL) {
 		posterURL = "anitrcli"
 	}
 
 	// Bölümleri al
 	episodes, episodeNames, isMovie, selectedSeasonIndex, err := getEpisodesAndNames(
-		source, false, selectedAnimeID, selectedAnimeSlug, animeData.Title,
+		ctx, source, false, selectedAnimeID, selectedAnimeSlug, animeData.Title,
 	)
+	stop() // spinneri durdur
 	if err != nil {
 		return fmt.Errorf("bölümler alınamadı: %w", err)
 	}
 
-	// Son bölümden devam et
-	if latestEpisodeIdx >= len(episodes) {
-		latestEpisodeIdx = len(episodes) - 1
+	// Devam edilecek bölüm güncel geçmişten seçilir
+	if history, err := utils.ReadAnimeHistory(); err == nil {
+		cfx.animeHistory = &history
 	}
 
 	// Oynatma döngüsüne gir
@@ -1700,55 +2476,270 @@ func quickResumeLastAnime(cfx *App, timestamp time.Time) error {
 		source, *cfx.selectedSource, episodes, episodeNames,
 		selectedAnimeID, selectedAnimeSlug, animeData.Title,
 		isMovie, selectedSeasonIndex, *cfx.uiMode, *cfx.rofiFlags,
-		posterURL, *cfx.disableRPC, timestamp, *cfx.animeHistory, cfx.logger,
+		posterURL, *cfx.disableRPC, cfx.playback, timestamp, *cfx.animeHistory, cfx.logger,
 	)
 
 	return err
 }
 
-// Ana uygulama döngüsünü yöneten fonksiyon
-func runMain(cmd *cobra.Command, f *flags.Flags, uiMode string, logger *utils.Logger) {
+// findPlayAnime, play alt komutu için sorguyu animeye çözer.
+// Sorgu geçerli bir ID/slug ise doğrudan kullanılır; değilse arama yapılır.
+// Arama tek sonuç ya da birebir eşleşen bir başlık döndürmezse seçim kullanıcıya sorulur.
+func findPlayAnime(ctx context.Context, cfx *App, query string, stop func()) (models.Anime, bool, error) {
+	source := *cfx.source
+	info, _ := sources.Lookup(source)
+
+	// ID ile çalışan kaynaklarda sayısal, slug ile çalışanlarda boşluksuz sorgular doğrudan denenir
+	_, numErr := strconv.Atoi(query)
+	if (!info.Capabilities.Slug && numErr == nil) || (info.Capabilities.Slug && !strings.ContainsAny(query, " \t")) {
+		anime, err := source.GetAnimeByID(ctx, query)
+		if err == nil && anime != nil {
+			if info.Capabilities.Slug && anime.Slug == nil {
+				anime.Slug = &query
+			}
+			return *anime, false, nil
+		}
+		if errors.Is(err, context.Canceled) {
+			return models.Anime{}, false, err
+		}
+	}
+
+	results, err := source.GetSearchData(ctx, query)
+	if err != nil {
+		return models.Anime{}, false, fmt.Errorf("%s araması başarısız: %w", info.Name, err)
+	}
+	if len(results) == 0 {
+		return models.Anime{}, false, fmt.Errorf("arama sonucu bulunamadı: %s", query)
+	}
+
+	isMovieOf := func(a models.Anime) bool {
+		return a.TitleType != nil && strings.ToLower(*a.TitleType) == "movie"
+	}
+
+	if len(results) == 1 {
+		return results[0], isMovieOf(results[0]), nil
+	}
+	for _, a := range results {
+		if strings.EqualFold(a.Title, query) {
+			return a, isMovieOf(a), nil
+		}
+	}
+
+	// Belirsiz sorgu → etkileşimli seçim
+	stop()
+	animeNames := make([]string, 0, len(results))
+	animeTypes := make([]string, 0, len(results))
+	for _, a := range results {
+		animeNames = append(animeNames, a.Title)
+		if isMovieOf(a) {
+			animeTypes = append(animeTypes, "movie")
+		} else {
+			animeTypes = append(animeTypes, "tv")
+		}
+	}
+	selected, isMovie, idx := selectAnime(*cfx.source, animeNames, results, *cfx.uiMode, false, *cfx.rofiFlags, animeTypes, cfx.logger)
+	if idx == -1 {
+		return models.Anime{}, false, tui.ErrGoBack
+	}
+	return selected, isMovie, nil
+}
+
+// playDirect, play alt komutunun akışını yürütür: animeyi ve bölümü bulur,
+// fansub ve çözünürlüğü bayraklara göre seçer ve bölümü etkileşimli akıştaki
+// playEpisode ile oynatır. Eşleşmeyen kalite ya da fansub kullanıcıya sorulur.
+func playDirect(cfx *App, opts flags.PlayOptions, query string, timestamp time.Time) error {
+	uiParams := internal.UiParams{Mode: *cfx.uiMode, RofiFlags: cfx.rofiFlags}
+	source := *cfx.source
+
+	ctx, stop := ui.Loading(context.Background(), uiParams, "Aranıyor...")
+	defer func() { stop() }()
+
+	selectedAnime, isMovie, err := findPlayAnime(ctx, cfx, query, stop)
+	if err != nil {
+		return err
+	}
+
+	// Seçim ekranı gösterildiyse yükleme göstergesi yeniden başlatılır
+	stop()
+	ctx, stop = ui.Loading(context.Background(), uiParams, "Yükleniyor...")
+
+	posterURL := selectedAnime.ImageURL
+	if !utils.IsValidImage(ctx, posterURL) {
+		posterURL = "anitrcli"
+	}
+
+	selectedAnimeID, selectedAnimeSlug := getAnimeIDs(source, selectedAnime)
+	episodes, episodeNames, isMovie, _, err := getEpisodesAndNames(
+		ctx, source, isMovie, selectedAnimeID, selectedAnimeSlug, selectedAnime.Title,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Bölüm belirtilmediyse etkileşimli akıştaki gibi geçmişe göre sıradaki bölüm
+	episodeIndex := resumeEpisodeIndex(*cfx.animeHistory, source, historyAnimeID(source, selectedAnimeID, selectedAnimeSlug), len(episodes))
+	if opts.Episode > 0 {
+		if opts.Episode > len(episodes) {
+			return fmt.Errorf("geçersiz bölüm: %d (1-%d arası olmalı)", opts.Episode, len(episodes))
+		}
+		episodeIndex = opts.Episode - 1
+	}
+	seasonIndex := sources.SeasonIndexOf(episodes[episodeIndex])
+
+	req := sources.WatchRequest{
+		Episodes:    episodes,
+		Index:       episodeIndex,
+		ID:          selectedAnimeID,
+		Slug:        &selectedAnimeSlug,
+		SeasonIndex: seasonIndex,
+		IsMovie:     isMovie,
+	}
+
+	// Fansub seçimi
+	if opts.Fansub != "" {
+		fansubs, err := sources.Fansubs(ctx, source, req)
+		if err != nil {
+			return err
+		}
+		if len(fansubs) == 0 {
+			return fmt.Errorf("%s fansub seçimini desteklemiyor", source.Source())
+		}
+		idx, ok := sources.FindFansub(fansubs, opts.Fansub)
+		if !ok {
+			stop()
+			names := make([]string, 0, len(fansubs))
+			for _, f := range fansubs {
+				names = append(names, *f.Name)
+			}
+			selected, err := showSelection(*cfx, names, fmt.Sprintf("'%s' bulunamadı, fansub seç ", opts.Fansub))
+			if err != nil {
+				return err
+			}
+			idx = slices.Index(names, selected)
+			ctx, stop = ui.Loading(context.Background(), uiParams, "Başlatılıyor...")
+		}
+		req.FansubIndex = idx
+	}
+
+	data, fansubs, err := updateWatchAPI(ctx, source, episodes, episodeIndex, selectedAnimeID, seasonIndex, req.FansubIndex, isMovie, &selectedAnimeSlug)
+	if err != nil {
+		return fmt.Errorf("bölüm oynatılamadı: %w", err)
+	}
+	labels := data["labels"].([]string)
+	urls := data["urls"].([]string)
+	if len(urls) == 0 {
+		return fmt.Errorf("oynatılabilir video bağlantısı bulunamadı")
+	}
+
+	// Çözünürlük seçimi; belirtilmezse en yüksek kalite
+	resolutionIdx := 0
+	if opts.Quality != "" {
+		want := strings.TrimSuffix(strings.ToLower(opts.Quality), "p") + "p"
+		resolutionIdx = slices.IndexFunc(labels, func(l string) bool { return strings.EqualFold(l, want) })
+		if resolutionIdx == -1 {
+			stop()
+			selected, err := showSelection(*cfx, labels, fmt.Sprintf("'%s' bulunamadı, çözünürlük seç ", opts.Quality))
+			if err != nil {
+				return err
+			}
+			resolutionIdx = max(slices.Index(labels, selected), 0)
+			ctx, stop = ui.Loading(context.Background(), uiParams, "Başlatılıyor...")
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	_, err = playEpisode(episodePlayback{
+		source:         source,
+		selectedSource: *cfx.selectedSource,
+		episodeNames:   episodeNames,
+		episodeIndex:   episodeIndex,
+		animeID:        selectedAnimeID,
+		animeSlug:      selectedAnimeSlug,
+		animeName:      selectedAnime.Title,
+		isMovie:        isMovie,
+		url:            urls[resolutionIdx],
+		subtitle:       data["caption_url"].(string),
+		fansub:         fansubName(fansubs, req.FansubIndex),
+		posterURL:      posterURL,
+		uiMode:         *cfx.uiMode,
+		rofiFlags:      *cfx.rofiFlags,
+		disableRPC:     *cfx.disableRPC,
+		player:         cfx.playback.player,
+		timestamp:      timestamp,
+		logger:         cfx.logger,
+	}, stop)
+	return err
+}
+
+// runPlay, play alt komutunu çalıştırır
+func runPlay(cmd *cobra.Command, f *flags.Flags, query string, logger *utils.Logger) {
+	currentApp := newApp(cmd, f, "tui", logger)
+
+	// --source bayrağı config'teki varsayılan kaynağı geçersiz kılar
+	if f.Play.Source != "" {
+		info, ok := sources.Get(f.Play.Source)
+		if !ok {
+			fmt.Printf("\033[31m[!] Bilinmeyen kaynak: %s\033[0m\n", f.Play.Source)
+			os.Exit(1)
+		}
+		currentApp.source = utils.Ptr(info.Source)
+		currentApp.selectedSource = utils.Ptr(info.Name)
+	}
+
+	err := playDirect(currentApp, f.Play, query, time.Now())
+	if err != nil && !errors.Is(err, tui.ErrGoBack) && !errors.Is(err, context.Canceled) {
+		logger.LogError(fmt.Errorf("play: %w", err))
+		fmt.Printf("\033[31m[!] %s\033[0m\n", err)
+		os.Exit(1)
+	}
+}
+
+// newApp, geçmişi ve config'i yükleyerek uygulama durumunu oluşturur
+func newApp(cmd *cobra.Command, f *flags.Flags, uiMode string, logger *utils.Logger) *App {
+	// Önceki oturumlardan kalan oynatıcı soketlerini temizle
+	player.SweepStaleSockets()
+
 	// RPC'yi devre dışı bırakma bayrağı ayarlanır
 	disableRPC := f.DisableRPC
 
-	// Güncellemeleri kontrol et
-	update.CheckUpdates()
-
 	// Geçmişi yükle
 	animeHistory, err := utils.ReadAnimeHistory()
 	if err != nil {
 		logger.LogError(fmt.Errorf(fmt.Sprintf("Geçmiş yüklenemedi: %s", err)))
 	}
 
+	// Varsayılan kaynak kayıt defterindeki ilk kaynaktır
+	defaultSource, ok := sources.Default()
+	if !ok {
+		logger.LogError(fmt.Errorf("kayıtlı anime kaynağı bulunamadı"))
+		fmt.Println("\033[31m[!] Kayıtlı anime kaynağı bulunamadı.\033[0m")
+		os.Exit(1)
+	}
+
 	// Uygulama durumunu başlat
 	currentApp := &App{
-		source:         utils.Ptr(models.AnimeSource(openanime.OpenAnime{})),
-		selectedSource: utils.Ptr("OpenAnime"),
+		source:         utils.Ptr(defaultSource.Source),
+		selectedSource: utils.Ptr(defaultSource.Name),
 		uiMode:         &uiMode,
 		rofiFlags:      &f.RofiFlags,
 		disableRPC:     &disableRPC,
 		animeHistory:   &animeHistory,
 		historyLimit:   0,
 		logger:         logger,
+		watchlist:      &watchlistState{},
 	}
 
 	// Configi yükle
 	cfg, err := utils.LoadConfig(filepath.Join(utils.ConfigDir(), "config.json"))
 	if err == nil {
-		if cfg.DefaultSource != "" {
-			// Config'te default_source varsa, onu kullan
-			switch strings.ToLower(cfg.DefaultSource) {
-			case "openanime":
-				currentApp.source = utils.Ptr(models.AnimeSource(openanime.OpenAnime{}))
-				currentApp.selectedSource = utils.Ptr("OpenAnime")
-			case "animecix":
-				currentApp.source = utils.Ptr(models.AnimeSource(animecix.AnimeCix{}))
-				currentApp.selectedSource = utils.Ptr("AnimeciX")
-			}
-		} else {
-			// Config'te default_source yoksa OpenAnime kullan
-			currentApp.source = utils.Ptr(models.AnimeSource(openanime.OpenAnime{}))
-			currentApp.selectedSource = utils.Ptr("OpenAnime")
+		// Config'te default_source varsa ve kayıtlıysa onu kullan,
+		// yoksa varsayılan kaynakla devam et
+		if info, ok := sources.Get(cfg.DefaultSource); ok && cfg.DefaultSource != "" {
+			currentApp.source = utils.Ptr(info.Source)
+			currentApp.selectedSource = utils.Ptr(info.Name)
 		}
 
 		// Config'de disable_rpc ayarı varsa
@@ -1758,12 +2749,57 @@ func runMain(cmd *cobra.Command, f *flags.Flags, uiMode string, logger *utils.Lo
 
 		// history_limit ayarı (default: 0 yani unlimited)
 		currentApp.historyLimit = cfg.HistoryLimit
+
+		// Geçmiş menüsünde tüm kaynakları gösterme ayarı (default: yalnızca seçili kaynak)
+		currentApp.historyAllSources = cfg.HistoryAllSources
+
+		// Oynatıcı ayarı (default: mpv)
+		currentApp.playback.player = player.Config{Backend: cfg.Player, Command: cfg.PlayerCommand}
+
+		// Otomatik oynatma ayarları
+		currentApp.playback.autoplay = cfg.Autoplay
+		currentApp.playback.autoplayLimit = cfg.AutoplayLimit
+
+		// Bölümün geçmişte izlendi sayılacağı eşik (default: bitişe 300 saniye kala)
+		if threshold, err := utils.ParseCompletionThreshold(cfg.CompletionThreshold); err != nil {
+			logger.LogError(err)
+		} else {
+			utils.SetCompletionThreshold(threshold)
+		}
+
+		// HTTP zaman aşımı ve yeniden deneme ayarları
+		httpx.Configure(cfg.HTTPOptions())
+
+		// Sezon bölümleri alınırken kullanılacak eşzamanlı istek sınırı
+		sources.SetWorkers(cfg.FetchWorkers)
 	}
 
 	if cmd.Flags().Changed("disable-rpc") {
 		currentApp.disableRPC = &disableRPC
 	}
 
+	// --player bayrağı config'teki oynatıcıyı geçersiz kılar
+	if f.Player != "" {
+		currentApp.playback.player.Backend = f.Player
+	}
+
+	// --autoplay ve --autoplay-limit bayrakları config'i geçersiz kılar
+	if cmd.Flags().Changed("autoplay") {
+		currentApp.playback.autoplay = f.Autoplay
+	}
+	if cmd.Flags().Changed("autoplay-limit") {
+		currentApp.playback.autoplayLimit = f.AutoplayLimit
+	}
+
+	return currentApp
+}
+
+// Ana uygulama döngüsünü yöneten fonksiyon
+func runMain(cmd *cobra.Command, f *flags.Flags, uiMode string, logger *utils.Logger) {
+	// Güncellemeleri kontrol et
+	update.CheckUpdates()
+
+	currentApp := newApp(cmd, f, uiMode, logger)
 	timestamp := time.Now()
 
 	// --go bayrağı kontrol edilir
@@ -1778,11 +2814,28 @@ func runMain(cmd *cobra.Command, f *flags.Flags, uiMode string, logger *utils.Lo
 		}
 	}
 
+	// İzleme listesi arka planda denetlenir; yeni bölüm varsa ana menüde rozet gösterilir
+	go func() {
+		if _, err := checkWatchlist(context.Background(), currentApp); err != nil {
+			logger.LogError(fmt.Errorf("izleme listesi denetlenemedi: %w", err))
+		}
+	}()
+
 	for {
 		mainMenu(currentApp, timestamp)
 	}
 }
 
+// findCommand, kök komutun doğrudan alt komutları arasından ada göre arama yapar
+func findCommand(root *cobra.Command, name string) *cobra.Command {
+	for _, c := range root.Commands() {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
 // Uygulama komutlarını çalıştıran giriş fonksiyonu
 func runApp() {
 	logger, err := utils.NewLogger()
@@ -1794,7 +2847,12 @@ func runApp() {
 
 	rootCmd, f := flags.NewFlagsCmd()
 
-	commands := rootCmd.Commands()
+	// play alt komutu tüm platformlarda etkileşimli akışın oynatma adımlarını kullanır
+	if playCmd := findCommand(rootCmd, "play"); playCmd != nil {
+		playCmd.Run = func(cmd *cobra.Command, args []string) {
+			runPlay(cmd, f, strings.Join(args, " "), logger)
+		}
+	}
 
 	if runtime.GOOS != "linux" {
 		// Windows ve Mac'te alt komut yok, doğrudan tui modunda çalıştır
@@ -1804,13 +2862,8 @@ func runApp() {
 		}
 	} else {
 		// Linux için alt komutlar varsa ayarla
-		var rofiCmd, tuiCmd *cobra.Command
-		if len(commands) > 0 {
-			rofiCmd = commands[0]
-		}
-		if len(commands) > 1 {
-			tuiCmd = commands[1]
-		}
+		rofiCmd := findCommand(rootCmd, "rofi")
+		tuiCmd := findCommand(rootCmd, "tui")
 
 		if rofiCmd != nil {
 			rofiCmd.Run = func(cmd *cobra.Command, args []string) {